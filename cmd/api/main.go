@@ -4,11 +4,19 @@ import (
 	"badbuddy/internal/delivery/http/rest"
 	"badbuddy/internal/delivery/http/ws"
 	"badbuddy/internal/infrastructure/database"
+	"badbuddy/internal/infrastructure/payment"
+	"badbuddy/internal/infrastructure/pubsub"
 	"badbuddy/internal/infrastructure/server"
 	"badbuddy/internal/repositories/postgres"
 	"badbuddy/internal/usecase/booking"
 	"badbuddy/internal/usecase/chat"
+	"badbuddy/internal/usecase/connection"
+	"badbuddy/internal/usecase/court"
 	"badbuddy/internal/usecase/facility"
+	"badbuddy/internal/usecase/pricing"
+	"badbuddy/internal/usecase/promotion"
+	"badbuddy/internal/usecase/review"
+	"badbuddy/internal/usecase/schedule"
 	"badbuddy/internal/usecase/session"
 	"badbuddy/internal/usecase/user"
 	"badbuddy/internal/usecase/venue"
@@ -21,6 +29,7 @@ import (
 
 	"github.com/go-co-op/gocron"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
 )
 
@@ -51,7 +60,11 @@ func main() {
 
 	app := server.NewFiberServer()
 
+	chatPubSub := newPubSub(db, dbConfig)
 	chatHub := ws.NewChatHub()
+	if err := chatHub.Listen(context.Background(), chatPubSub); err != nil {
+		log.Fatalf("Failed to subscribe to chat events: %v", err)
+	}
 
 	userRepo := postgres.NewUserRepository(db)
 	userUseCase := user.NewUserUseCase(userRepo, "your-jwt-secret", 24*time.Hour)
@@ -68,24 +81,60 @@ func main() {
 	venueHandler := rest.NewVenueHandler(venueUseCase, facilityUseCase, userUseCase)
 	venueHandler.SetupVenueRoutes(app)
 
-	chatRepo := postgres.NewChatRepository(db)
+	chatRepo := ws.NewMembershipChatRepository(postgres.NewChatRepository(db), chatPubSub)
 	chatUseCase := chat.NewChatUseCase(chatRepo, userRepo)
-	chatHandler := rest.NewChatHandler(chatUseCase, chatHub)
+	chatHandler := rest.NewChatHandler(chatUseCase, chatPubSub)
 	chatHandler.SetupChatRoutes(app)
-	
+
+	txManager := postgres.NewTransactionManager(db)
+	bookingRepo := postgres.NewBookingRepository(db)
+	courtRepo := postgres.NewCourtRepository(db)
+	pricingRepo := postgres.NewPricingRepository(db)
+	promotionRepo := postgres.NewPromotionRepository(db)
+	scheduleRepo := postgres.NewScheduleRepository(db)
+	maintenanceRepo := postgres.NewMaintenanceRepository(db)
+
 	sessionRepo := postgres.NewSessionRepository(db)
-	sessionUseCase := session.NewSessionUseCase(sessionRepo, venueRepo, chatRepo)
+	paymentProvider, paymentSimulator := newPaymentProvider()
+	bookingUseCase := booking.NewBookingUseCase(bookingRepo, courtRepo, venueRepo, userRepo, sessionRepo, pricingRepo, promotionRepo, scheduleRepo, maintenanceRepo, txManager, paymentProvider)
+	sessionUseCase := session.NewSessionUseCase(sessionRepo, venueRepo, chatRepo, courtRepo, bookingRepo, pricingRepo, scheduleRepo, maintenanceRepo, txManager, bookingUseCase)
 	sessionHandler := rest.NewSessionHandler(sessionUseCase)
 	sessionHandler.SetupSessionRoutes(app)
 
-	bookingRepo := postgres.NewBookingRepository(db)
-	courtRepo := postgres.NewCourtRepository(db)
-	bookingUseCase := booking.NewBookingUseCase(bookingRepo, courtRepo, venueRepo, userRepo)
+	reviewRepo := postgres.NewPlayerReviewRepository(db)
+	reviewUseCase := review.NewReviewUseCase(reviewRepo, sessionRepo)
+	reviewHandler := rest.NewReviewHandler(reviewUseCase)
+	reviewHandler.SetupReviewRoutes(app)
+
+	connectionRepo := postgres.NewConnectionRepository(db)
+	connectionUseCase := connection.NewConnectionUseCase(connectionRepo, userRepo)
+	connectionHandler := rest.NewConnectionHandler(connectionUseCase)
+	connectionHandler.SetupConnectionRoutes(app)
+
 	bookingHandler := rest.NewBookingHandler(bookingUseCase)
 	bookingHandler.SetupBookingRoutes(app)
 
+	paymentHandler := rest.NewPaymentHandler(bookingUseCase, paymentSimulator)
+	paymentHandler.SetupPaymentRoutes(app)
+
+	courtUseCase := court.NewCourtUseCase(courtRepo, venueRepo, bookingRepo, maintenanceRepo, sessionRepo, txManager)
+	courtHandler := rest.NewCourtHandler(courtUseCase, venueUseCase, userUseCase)
+	courtHandler.SetupCourtRoutes(app)
+
+	pricingUseCase := pricing.NewPricingUseCase(pricingRepo, venueRepo, courtRepo)
+	pricingHandler := rest.NewPricingHandler(pricingUseCase, venueUseCase, userUseCase)
+	pricingHandler.SetupPricingRoutes(app)
+
+	promotionUseCase := promotion.NewPromotionUseCase(promotionRepo, venueRepo, courtRepo, userRepo)
+	promotionHandler := rest.NewPromotionHandler(promotionUseCase)
+	promotionHandler.SetupPromotionRoutes(app)
+
+	scheduleUseCase := schedule.NewScheduleUseCase(scheduleRepo, venueRepo, courtRepo, bookingRepo)
+	scheduleHandler := rest.NewScheduleHandler(scheduleUseCase, venueUseCase, userUseCase)
+	scheduleHandler.SetupScheduleRoutes(app)
+
 	cronJob(bookingUseCase)
-	app.Get("/ws/:chat_id", ws.ChatWebSocketHandler(chatHub))
+	app.Get("/ws/:chat_id", ws.ChatWebSocketHandler(chatHub, chatPubSub, chatRepo, chatUseCase))
 
 	//add heatlh check and ready check
 
@@ -128,6 +177,60 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// Helper function to read an environment variable as a boolean or return a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// newPaymentProvider picks the payment gateway from PAYMENT_PROVIDER, which must be set.
+// The fake provider's simulator completes checkouts without paying, so it is only
+// returned when PAYMENT_SIMULATOR_ENABLED is set for local development.
+func newPaymentProvider() (payment.Provider, payment.Simulator) {
+	provider := getEnv("PAYMENT_PROVIDER", "")
+	if provider == "" {
+		log.Fatal("PAYMENT_PROVIDER must be set")
+	}
+
+	webhookSecret := getEnv("PAYMENT_WEBHOOK_SECRET", "")
+	if webhookSecret == "" {
+		log.Fatal("PAYMENT_WEBHOOK_SECRET must be set")
+	}
+
+	switch provider {
+	case "fake":
+		fake := payment.NewFakeProvider(
+			webhookSecret,
+			getEnv("PUBLIC_BASE_URL", "http://localhost:"+getEnv("PORT", "8004")),
+		)
+		if !getEnvAsBool("PAYMENT_SIMULATOR_ENABLED", false) {
+			return fake, nil
+		}
+		log.Println("Payment simulator enabled: payments can be completed without paying")
+		return fake, fake
+	default:
+		log.Fatalf("Unknown payment provider: %s", provider)
+		return nil, nil
+	}
+}
+
+// newPubSub picks how chat events reach the sockets. Use postgres when running more than
+// one instance, so users connected to different instances see each other's messages.
+func newPubSub(db *sqlx.DB, dbConfig database.Config) pubsub.PubSub {
+	switch driver := getEnv("PUBSUB_DRIVER", "memory"); driver {
+	case "memory":
+		return pubsub.NewMemoryPubSub()
+	case "postgres":
+		return pubsub.NewPostgresPubSub(db, dbConfig.DSN())
+	default:
+		log.Fatalf("Unknown pubsub driver: %s", driver)
+		return nil
+	}
+}
+
 func cronJob(bookingUseCase booking.UseCase) {
 	cron := gocron.NewScheduler(time.UTC)
 
@@ -146,5 +249,23 @@ func cronJob(bookingUseCase booking.UseCase) {
 
 	})
 
+	// job 2: release pending bookings that were not paid in time
+	cron.Every("1m").Do(func() {
+		ctx := context.Background()
+
+		if err := bookingUseCase.ExpirePendingBookings(ctx); err != nil {
+			log.Printf("Error expiring pending bookings: %v", err)
+		}
+	})
+
+	// job 3: send refunds to the payment gateway that could not be sent when recorded
+	cron.Every("1m").Do(func() {
+		ctx := context.Background()
+
+		if err := bookingUseCase.SettleRefunds(ctx); err != nil {
+			log.Printf("Error settling refunds: %v", err)
+		}
+	})
+
 	cron.StartAsync()
 }
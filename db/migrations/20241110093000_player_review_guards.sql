@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE player_reviews ADD CONSTRAINT player_reviews_rating_check CHECK (rating BETWEEN 1 AND 5);
+ALTER TABLE player_reviews ADD CONSTRAINT player_reviews_not_self_check CHECK (reviewer_id <> reviewed_id);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_reviewer ON player_reviews USING btree (reviewer_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_reviews_reviewer;
+
+ALTER TABLE player_reviews DROP CONSTRAINT IF EXISTS player_reviews_not_self_check;
+ALTER TABLE player_reviews DROP CONSTRAINT IF EXISTS player_reviews_rating_check;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE player_connections ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'accepted';
+ALTER TABLE player_connections ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE player_connections ADD CONSTRAINT player_connections_status_check CHECK (status IN ('pending', 'accepted'));
+ALTER TABLE player_connections ADD CONSTRAINT player_connections_not_self_check CHECK (player1_id <> player2_id);
+
+-- A pair of players can only be connected once, whoever sent the request
+CREATE UNIQUE INDEX IF NOT EXISTS player_connections_pair_key ON player_connections USING btree (LEAST(player1_id, player2_id), GREATEST(player1_id, player2_id));
+CREATE INDEX IF NOT EXISTS idx_player_connections_player2 ON player_connections USING btree (player2_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_player_connections_player2;
+DROP INDEX IF EXISTS player_connections_pair_key;
+
+ALTER TABLE player_connections DROP CONSTRAINT IF EXISTS player_connections_not_self_check;
+ALTER TABLE player_connections DROP CONSTRAINT IF EXISTS player_connections_status_check;
+ALTER TABLE player_connections DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE player_connections DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Bookings created on behalf of a play session point back to it
+ALTER TABLE court_bookings ADD COLUMN session_id uuid REFERENCES play_sessions(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_court_bookings_session ON court_bookings USING btree (session_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_court_bookings_session;
+ALTER TABLE court_bookings DROP COLUMN IF EXISTS session_id;
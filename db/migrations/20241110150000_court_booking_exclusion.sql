@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Two active bookings can never hold the same court at overlapping times
+ALTER TABLE court_bookings ADD CONSTRAINT court_bookings_no_overlap EXCLUDE USING gist (
+    court_id WITH =,
+    tsrange(booking_date + start_time, booking_date + end_time) WITH &&
+) WHERE (status <> 'cancelled');
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE court_bookings DROP CONSTRAINT IF EXISTS court_bookings_no_overlap;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Minutes a pending booking holds its slot before it is released unpaid
+ALTER TABLE venues ADD COLUMN booking_hold_minutes int4 NOT NULL DEFAULT 30;
+ALTER TABLE venues ADD CONSTRAINT venues_booking_hold_minutes_check CHECK (booking_hold_minutes > 0);
+
+ALTER TABLE court_bookings ADD COLUMN hold_expires_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_court_bookings_pending_hold ON court_bookings USING btree (hold_expires_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS "booking_status_history" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "from_status" varchar(20),
+    "to_status" varchar(20) NOT NULL,
+    "reason" varchar(50) NOT NULL,
+    "changed_by" uuid,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "booking_status_history_booking_id_fkey" FOREIGN KEY ("booking_id") REFERENCES "court_bookings"("id") ON DELETE CASCADE,
+    CONSTRAINT "booking_status_history_changed_by_fkey" FOREIGN KEY ("changed_by") REFERENCES "users"("id"),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_status_history_booking ON booking_status_history USING btree (booking_id, created_at);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS booking_status_history;
+
+DROP INDEX IF EXISTS idx_court_bookings_pending_hold;
+ALTER TABLE court_bookings DROP COLUMN IF EXISTS hold_expires_at;
+
+ALTER TABLE venues DROP CONSTRAINT IF EXISTS venues_booking_hold_minutes_check;
+ALTER TABLE venues DROP COLUMN IF EXISTS booking_hold_minutes;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Tiered refund rules, e.g. [{"hours_before": 48, "refund_percent": 100}, {"hours_before": 12, "refund_percent": 50}]
+ALTER TABLE venues ADD COLUMN cancellation_policy jsonb;
+
+CREATE TABLE IF NOT EXISTS "refunds" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "payment_id" uuid NOT NULL,
+    "amount" numeric(10,2) NOT NULL,
+    "refund_percent" int4 NOT NULL,
+    "reason" varchar(50) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "refunds_booking_id_fkey" FOREIGN KEY ("booking_id") REFERENCES "court_bookings"("id") ON DELETE CASCADE,
+    CONSTRAINT "refunds_payment_id_fkey" FOREIGN KEY ("payment_id") REFERENCES "payments"("id") ON DELETE CASCADE,
+    CONSTRAINT "refunds_amount_check" CHECK (amount > 0),
+    CONSTRAINT "refunds_refund_percent_check" CHECK (refund_percent BETWEEN 1 AND 100),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_booking ON refunds USING btree (booking_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS refunds;
+ALTER TABLE venues DROP COLUMN IF EXISTS cancellation_policy;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Card and QR payments carry the gateway's intent ID, which webhooks are matched against
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments USING btree (transaction_id) WHERE transaction_id IS NOT NULL;
+
+ALTER TABLE refunds ADD COLUMN provider_refund_id varchar(255);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE refunds DROP COLUMN IF EXISTS provider_refund_id;
+DROP INDEX IF EXISTS idx_payments_transaction_id;
@@ -0,0 +1,18 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- New enum values cannot be used in the transaction that adds them, hence NO TRANSACTION
+ALTER TYPE participant_status_enum ADD VALUE IF NOT EXISTS 'waitlisted';
+
+-- Waitlisted players are promoted in the order they joined
+CREATE INDEX IF NOT EXISTS idx_participants_waitlist ON session_participants USING btree (session_id, joined_at) WHERE status = 'waitlisted';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_participants_waitlist;
+-- Postgres cannot drop an enum value, so waitlisted players are cancelled and the value is left unused
+UPDATE session_participants SET status = 'cancelled', cancelled_at = NOW() WHERE status = 'waitlisted';
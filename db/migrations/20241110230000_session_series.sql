@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Weekdays are a bitmask where bit 0 is Sunday and bit 6 is Saturday
+CREATE TABLE IF NOT EXISTS "session_series" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "host_id" uuid NOT NULL,
+    "venue_id" uuid NOT NULL,
+    "weekdays" int4 NOT NULL,
+    "interval_weeks" int4 NOT NULL DEFAULT 1,
+    "starts_on" date NOT NULL,
+    "until_date" date,
+    "occurrence_count" int4,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "session_series_host_id_fkey" FOREIGN KEY ("host_id") REFERENCES "users"("id"),
+    CONSTRAINT "session_series_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id"),
+    CONSTRAINT "session_series_weekdays_check" CHECK (weekdays > 0 AND weekdays < 128),
+    CONSTRAINT "session_series_interval_weeks_check" CHECK (interval_weeks > 0),
+    CONSTRAINT "session_series_end_check" CHECK (until_date IS NOT NULL OR occurrence_count IS NOT NULL),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE play_sessions ADD COLUMN series_id uuid;
+ALTER TABLE play_sessions ADD CONSTRAINT "play_sessions_series_id_fkey" FOREIGN KEY ("series_id") REFERENCES "session_series"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_play_sessions_series ON play_sessions USING btree (series_id, session_date) WHERE series_id IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_play_sessions_series;
+ALTER TABLE play_sessions DROP CONSTRAINT IF EXISTS play_sessions_series_id_fkey;
+ALTER TABLE play_sessions DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS session_series;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Contract bookings: the same court and time every week across a date range.
+-- Weekdays are a bitmask where bit 0 is Sunday and bit 6 is Saturday.
+CREATE TABLE IF NOT EXISTS "booking_series" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "court_id" uuid NOT NULL,
+    "weekdays" int4 NOT NULL,
+    "starts_on" date NOT NULL,
+    "ends_on" date NOT NULL,
+    "start_time" time NOT NULL,
+    "end_time" time NOT NULL,
+    "notes" text,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "booking_series_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    CONSTRAINT "booking_series_court_id_fkey" FOREIGN KEY ("court_id") REFERENCES "courts"("id"),
+    CONSTRAINT "booking_series_weekdays_check" CHECK (weekdays > 0 AND weekdays < 128),
+    CONSTRAINT "booking_series_dates_check" CHECK (ends_on >= starts_on),
+    CONSTRAINT "booking_series_times_check" CHECK (end_time > start_time),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE court_bookings ADD COLUMN series_id uuid;
+ALTER TABLE court_bookings ADD CONSTRAINT "court_bookings_series_id_fkey" FOREIGN KEY ("series_id") REFERENCES "booking_series"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_court_bookings_series ON court_bookings USING btree (series_id) WHERE series_id IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_court_bookings_series;
+ALTER TABLE court_bookings DROP CONSTRAINT IF EXISTS court_bookings_series_id_fkey;
+ALTER TABLE court_bookings DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS booking_series;
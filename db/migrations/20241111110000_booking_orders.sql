@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- An order groups bookings checked out together. They are paid with a single
+-- gateway charge, recorded as one payment row per booking for its share.
+CREATE TABLE IF NOT EXISTS "booking_orders" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "total_amount" numeric(10,2) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "booking_orders_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE court_bookings ADD COLUMN order_id uuid;
+ALTER TABLE court_bookings ADD CONSTRAINT "court_bookings_order_id_fkey" FOREIGN KEY ("order_id") REFERENCES "booking_orders"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_court_bookings_order ON court_bookings USING btree (order_id) WHERE order_id IS NOT NULL;
+
+ALTER TABLE payments ADD COLUMN order_id uuid;
+ALTER TABLE payments ADD CONSTRAINT "payments_order_id_fkey" FOREIGN KEY ("order_id") REFERENCES "booking_orders"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_order ON payments USING btree (order_id) WHERE order_id IS NOT NULL;
+
+-- The bookings of an order share the gateway intent of the order's payment
+DROP INDEX IF EXISTS idx_payments_transaction_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_transaction_booking ON payments USING btree (transaction_id, booking_id) WHERE transaction_id IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_payments_transaction_booking;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments USING btree (transaction_id) WHERE transaction_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_payments_order;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_order_id_fkey;
+ALTER TABLE payments DROP COLUMN IF EXISTS order_id;
+DROP INDEX IF EXISTS idx_court_bookings_order;
+ALTER TABLE court_bookings DROP CONSTRAINT IF EXISTS court_bookings_order_id_fkey;
+ALTER TABLE court_bookings DROP COLUMN IF EXISTS order_id;
+DROP TABLE IF EXISTS booking_orders;
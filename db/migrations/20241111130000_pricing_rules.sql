@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Pricing rules replace a court's price per hour inside a daily time window.
+-- Weekdays are a bitmask where bit 0 is Sunday and bit 6 is Saturday.
+CREATE TABLE IF NOT EXISTS "venue_pricing_rules" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "venue_id" uuid NOT NULL,
+    "court_id" uuid,
+    "name" varchar(100) NOT NULL,
+    "weekdays" int4 NOT NULL DEFAULT 0,
+    "holidays_only" bool NOT NULL DEFAULT false,
+    "start_time" time NOT NULL,
+    "end_time" time NOT NULL,
+    "start_date" date,
+    "end_date" date,
+    "price_per_hour" numeric(10,2) NOT NULL,
+    "priority" int4 NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "venue_pricing_rules_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_pricing_rules_court_id_fkey" FOREIGN KEY ("court_id") REFERENCES "courts"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_pricing_rules_weekdays_check" CHECK (weekdays >= 0 AND weekdays < 128),
+    CONSTRAINT "venue_pricing_rules_days_check" CHECK (holidays_only OR weekdays > 0),
+    CONSTRAINT "venue_pricing_rules_times_check" CHECK (end_time > start_time),
+    CONSTRAINT "venue_pricing_rules_dates_check" CHECK (start_date IS NULL OR end_date IS NULL OR end_date >= start_date),
+    CONSTRAINT "venue_pricing_rules_price_check" CHECK (price_per_hour > 0),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_venue_pricing_rules_venue ON venue_pricing_rules USING btree (venue_id);
+
+CREATE TABLE IF NOT EXISTS "venue_holidays" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "venue_id" uuid NOT NULL,
+    "holiday_date" date NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "venue_holidays_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_holidays_venue_date_key" UNIQUE ("venue_id", "holiday_date"),
+    PRIMARY KEY ("id")
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS venue_holidays;
+DROP TABLE IF EXISTS venue_pricing_rules;
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Promotions are discount codes run by a venue, or across every venue when venue_id is NULL.
+-- court_ids limits a venue's promotion to some of its courts; NULL means every court.
+CREATE TABLE IF NOT EXISTS "promotions" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "code" varchar(50) NOT NULL,
+    "description" text,
+    "venue_id" uuid,
+    "court_ids" uuid[],
+    "discount_type" varchar(20) NOT NULL,
+    "discount_value" numeric(10,2) NOT NULL,
+    "min_spend" numeric(10,2) NOT NULL DEFAULT 0,
+    "max_uses" int4,
+    "max_uses_per_user" int4,
+    "uses_count" int4 NOT NULL DEFAULT 0,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "active" bool NOT NULL DEFAULT true,
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "promotions_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    CONSTRAINT "promotions_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users"("id"),
+    CONSTRAINT "promotions_discount_type_check" CHECK (discount_type IN ('percentage', 'fixed')),
+    CONSTRAINT "promotions_discount_value_check" CHECK (discount_value > 0 AND (discount_type <> 'percentage' OR discount_value < 100)),
+    CONSTRAINT "promotions_uses_check" CHECK (max_uses IS NULL OR uses_count <= max_uses),
+    CONSTRAINT "promotions_window_check" CHECK (ends_at > starts_at),
+    PRIMARY KEY ("id")
+);
+
+-- Codes are matched case-insensitively
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_code ON promotions USING btree (upper(code));
+CREATE INDEX IF NOT EXISTS idx_promotions_venue ON promotions USING btree (venue_id);
+
+CREATE TABLE IF NOT EXISTS "promotion_redemptions" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "promotion_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "discount_amount" numeric(10,2) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "promotion_redemptions_promotion_id_fkey" FOREIGN KEY ("promotion_id") REFERENCES "promotions"("id") ON DELETE CASCADE,
+    CONSTRAINT "promotion_redemptions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    CONSTRAINT "promotion_redemptions_booking_id_fkey" FOREIGN KEY ("booking_id") REFERENCES "court_bookings"("id") ON DELETE CASCADE,
+    CONSTRAINT "promotion_redemptions_booking_id_key" UNIQUE ("booking_id"),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_user ON promotion_redemptions USING btree (promotion_id, user_id);
+
+-- total_amount stays the amount due, discount_amount records what the promotion took off it
+ALTER TABLE court_bookings
+    ADD COLUMN IF NOT EXISTS promotion_id uuid REFERENCES promotions(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS discount_amount numeric(10,2) NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE court_bookings
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS promotion_id;
+
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotions;
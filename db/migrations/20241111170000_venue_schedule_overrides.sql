@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Date-specific changes to a venue's weekly opening hours. A closed override shuts the venue,
+-- or a single court when court_id is set, between start_time and end_time or all day when
+-- they are NULL. Otherwise start_time and end_time are that day's opening hours.
+CREATE TABLE IF NOT EXISTS "venue_schedule_overrides" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "venue_id" uuid NOT NULL,
+    "court_id" uuid,
+    "override_date" date NOT NULL,
+    "closed" bool NOT NULL,
+    "start_time" time,
+    "end_time" time,
+    "reason" varchar(255),
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "venue_schedule_overrides_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_schedule_overrides_court_id_fkey" FOREIGN KEY ("court_id") REFERENCES "courts"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_schedule_overrides_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users"("id"),
+    CONSTRAINT "venue_schedule_overrides_window_check" CHECK (
+        (start_time IS NULL AND end_time IS NULL AND closed) OR
+        (start_time IS NOT NULL AND end_time IS NOT NULL AND end_time > start_time)
+    ),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_venue_schedule_overrides_venue_date ON venue_schedule_overrides USING btree (venue_id, override_date);
+
+-- A venue or court can have only one set of special opening hours per date
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venue_schedule_overrides_hours
+    ON venue_schedule_overrides (venue_id, COALESCE(court_id, '00000000-0000-0000-0000-000000000000'::uuid), override_date)
+    WHERE NOT closed;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS venue_schedule_overrides;
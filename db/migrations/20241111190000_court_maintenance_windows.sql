@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+CREATE TABLE IF NOT EXISTS "court_maintenance_windows" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "court_id" uuid NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "reason" varchar(255),
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "court_maintenance_windows_court_id_fkey" FOREIGN KEY ("court_id") REFERENCES "courts"("id") ON DELETE CASCADE,
+    CONSTRAINT "court_maintenance_windows_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users"("id"),
+    CONSTRAINT "court_maintenance_windows_window_check" CHECK (ends_at > starts_at),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_court_maintenance_windows_court ON court_maintenance_windows USING btree (court_id, ends_at);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS court_maintenance_windows;
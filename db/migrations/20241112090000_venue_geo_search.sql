@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Venues are searched by distance with earthdistance, which stores points as cubes so a
+-- radius search can use a GiST index through earth_box
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+-- Coordinates of 0, 0 mean the venue has not set its location
+ALTER TABLE venues
+    ADD COLUMN IF NOT EXISTS latitude float8 NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS longitude float8 NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_venues_earth ON venues USING gist (ll_to_earth(latitude, longitude));
+-- Map views search a bounding box on the raw coordinates
+CREATE INDEX IF NOT EXISTS idx_venues_lat_lng ON venues USING btree (latitude, longitude);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_venues_lat_lng;
+DROP INDEX IF EXISTS idx_venues_earth;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Payloads too large for NOTIFY are kept here briefly; the notification carries their id
+CREATE TABLE IF NOT EXISTS "pubsub_payloads" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "payload" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_pubsub_payloads_created_at ON pubsub_payloads USING btree (created_at);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS pubsub_payloads;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- The id a client gives a message it sends, so a resent message is saved only once
+ALTER TABLE chat_messages ADD COLUMN client_message_id varchar(100);
+
+CREATE UNIQUE INDEX IF NOT EXISTS chat_messages_client_message_id_key ON chat_messages USING btree (chat_id, sender_id, client_message_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS chat_messages_client_message_id_key;
+ALTER TABLE chat_messages DROP COLUMN IF EXISTS client_message_id;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- Gateway refunds are recorded as pending and sent to the gateway once the cancellation has
+-- committed; refunds recorded before this were already settled
+ALTER TABLE refunds ADD COLUMN status varchar(20) NOT NULL DEFAULT 'completed';
+ALTER TABLE refunds ADD CONSTRAINT refunds_status_check CHECK (status IN ('pending', 'completed'));
+
+CREATE INDEX IF NOT EXISTS idx_refunds_pending ON refunds USING btree (created_at) WHERE status = 'pending';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_refunds_pending;
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_status_check;
+ALTER TABLE refunds DROP COLUMN IF EXISTS status;
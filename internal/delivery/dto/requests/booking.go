@@ -7,12 +7,39 @@ type CreateBookingRequest struct {
 	StartTime string  `json:"start_time" validate:"required,datetime"`
 	EndTime   string  `json:"end_time" validate:"required,datetime"`
 	Notes     *string `json:"notes" validate:"omitempty,min=1,max=500"`
+	PromoCode *string `json:"promo_code" validate:"omitempty,min=3,max=50"`
+}
+
+// CreateRecurringBookingRequest represents the request to book the same court and time every week.
+// Without AllowPartial any conflicting date aborts the whole request.
+type CreateRecurringBookingRequest struct {
+	CourtID      string   `json:"court_id" validate:"required,uuid"`
+	Weekdays     []string `json:"weekdays" validate:"required,min=1,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	StartDate    string   `json:"start_date" validate:"required,datetime"`
+	EndDate      string   `json:"end_date" validate:"required,datetime"`
+	StartTime    string   `json:"start_time" validate:"required,datetime"`
+	EndTime      string   `json:"end_time" validate:"required,datetime"`
+	Notes        *string  `json:"notes" validate:"omitempty,min=1,max=500"`
+	AllowPartial bool     `json:"allow_partial"`
+}
+
+// CreateBookingOrderRequest represents a checkout of several court slots at once
+type CreateBookingOrderRequest struct {
+	Items []BookingOrderItemRequest `json:"items" validate:"required,min=1,max=10,dive"`
+	Notes *string                   `json:"notes" validate:"omitempty,min=1,max=500"`
+}
+
+// BookingOrderItemRequest represents a single court slot of a checkout
+type BookingOrderItemRequest struct {
+	CourtID   string `json:"court_id" validate:"required,uuid"`
+	Date      string `json:"date" validate:"required,datetime"`
+	StartTime string `json:"start_time" validate:"required,datetime"`
+	EndTime   string `json:"end_time" validate:"required,datetime"`
 }
 
 // UpdateBookingRequest represents the request to update an existing booking
 type UpdateBookingRequest struct {
-	Status string  `json:"status" validate:"omitempty,oneof=confirmed cancelled"`
-	Notes  *string `json:"notes" validate:"omitempty,min=1,max=500"`
+	Notes *string `json:"notes" validate:"omitempty,min=1,max=500"`
 }
 
 // CreatePaymentRequest represents the request to create a payment for a booking
@@ -20,12 +47,14 @@ type CreatePaymentRequest struct {
 	PaymentMethod string  `json:"payment_method" validate:"required,oneof=cash transfer card qr"`
 	Amount        float64 `json:"amount" validate:"required,gt=0"`
 	TransactionID *string `json:"transaction_id" validate:"omitempty,min=1"`
+	// PromoCode applies a promotion to a booking that does not have one yet. Amount must be the discounted total.
+	PromoCode *string `json:"promo_code" validate:"omitempty,min=3,max=50"`
 }
 
-//UpdatePaymentRequest represents the request to update a payment for a booking
+// UpdatePaymentRequest represents the request to update a payment for a booking
 type UpdatePaymentRequest struct {
-	PaymentMethod string `json:"payment_method" validate:"omitempty,oneof=cash transfer card qr"`
-	Status        string `json:"status" validate:"required,oneof=pending confirmed cancelled"`
+	PaymentMethod string `json:"payment_method" validate:"omitempty,oneof=cash transfer"`
+	Status        string `json:"status" validate:"required,oneof=completed failed"`
 }
 
 // ListBookingsRequest represents the request to list bookings with filters
@@ -46,3 +75,28 @@ type CheckAvailabilityRequest struct {
 	StartTime string `json:"start_time" validate:"required,datetime"`
 	EndTime   string `json:"end_time" validate:"required,datetime"`
 }
+
+// VenueAvailabilityRequest represents the request for the slot grid of a venue's courts
+type VenueAvailabilityRequest struct {
+	VenueID string `json:"venue_id" validate:"required,uuid"`
+	Date    string `json:"date" validate:"required,datetime=2006-01-02"`
+	Days    int    `json:"days" validate:"omitempty,min=1,max=7"`
+}
+
+// SearchAvailabilityRequest represents the request to find free courts across venues.
+// Slots of Duration minutes are searched between From and To; without a duration the
+// whole window is searched as one slot.
+type SearchAvailabilityRequest struct {
+	Date       string   `json:"date" validate:"required,datetime=2006-01-02"`
+	From       string   `json:"from" validate:"required,datetime=15:04"`
+	To         string   `json:"to" validate:"required,datetime=15:04"`
+	Duration   int      `json:"duration" validate:"omitempty,min=30,max=240"`
+	Latitude   *float64 `json:"lat" validate:"omitempty,latitude"`
+	Longitude  *float64 `json:"lng" validate:"omitempty,longitude"`
+	RadiusKm   float64  `json:"radius_km" validate:"omitempty,gt=0,max=100"`
+	MaxPrice   float64  `json:"max_price" validate:"omitempty,gt=0"`
+	Facilities []string `json:"facilities"`
+	SortBy     string   `json:"sort_by" validate:"omitempty,oneof=distance price"`
+	Limit      int      `json:"limit" validate:"omitempty,min=1,max=50"`
+	Offset     int      `json:"offset" validate:"omitempty,min=0"`
+}
@@ -2,5 +2,7 @@ package requests
 
 type SendAndUpdateMessageRequest struct {
 	Message string `json:"message"`
+	// ClientMessageID is an optional id chosen by the client when sending. Resending a
+	// message with the same id returns the message already sent instead of a new one.
+	ClientMessageID *string `json:"client_message_id,omitempty"`
 }
-
@@ -12,7 +12,6 @@ type UpdateCourtRequest struct {
 	Name         string  `json:"name" validate:"omitempty,min=2,max=100"`
 	Description  string  `json:"description" validate:"omitempty,max=500"`
 	PricePerHour float64 `json:"price_per_hour" validate:"omitempty,gt=0"`
-	Status       string  `json:"status" validate:"omitempty,oneof=available occupied maintenance"`
 }
 
 type UpdateCourtStatusRequest struct {
@@ -21,6 +20,7 @@ type UpdateCourtStatusRequest struct {
 
 type ListCourtsRequest struct {
 	VenueID  string  `json:"venue_id" validate:"omitempty,uuid"`
+	OwnerID  string  `json:"owner_id" validate:"omitempty,uuid"`
 	Status   string  `json:"status" validate:"omitempty,oneof=available occupied maintenance"`
 	Location string  `json:"location" validate:"omitempty,max=100"`
 	PriceMin float64 `json:"price_min" validate:"omitempty,min=0"`
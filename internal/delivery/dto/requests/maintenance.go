@@ -0,0 +1,8 @@
+package requests
+
+// CreateMaintenanceWindowRequest represents the request to schedule maintenance of a court
+type CreateMaintenanceWindowRequest struct {
+	StartsAt string  `json:"starts_at" validate:"required,datetime"`
+	EndsAt   string  `json:"ends_at" validate:"required,datetime"`
+	Reason   *string `json:"reason" validate:"omitempty,max=255"`
+}
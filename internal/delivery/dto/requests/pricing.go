@@ -0,0 +1,21 @@
+package requests
+
+// CreatePricingRuleRequest represents the request to add a pricing rule to a venue
+type CreatePricingRuleRequest struct {
+	CourtID      *string  `json:"court_id" validate:"omitempty,uuid"`
+	Name         string   `json:"name" validate:"required,min=1,max=100"`
+	Weekdays     []string `json:"weekdays" validate:"omitempty,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	HolidaysOnly bool     `json:"holidays_only"`
+	StartTime    string   `json:"start_time" validate:"required,datetime"`
+	EndTime      string   `json:"end_time" validate:"required,datetime"`
+	StartDate    *string  `json:"start_date" validate:"omitempty,datetime"`
+	EndDate      *string  `json:"end_date" validate:"omitempty,datetime"`
+	PricePerHour float64  `json:"price_per_hour" validate:"required,gt=0"`
+	Priority     int      `json:"priority"`
+}
+
+// CreateHolidayRequest represents the request to add a date to a venue's holiday calendar
+type CreateHolidayRequest struct {
+	Date string `json:"date" validate:"required,datetime"`
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,17 @@
+package requests
+
+// CreatePromotionRequest represents the request to create a discount code. Without a
+// venue the promotion applies everywhere and can only be created by an admin.
+type CreatePromotionRequest struct {
+	Code           string   `json:"code" validate:"required,min=3,max=50,alphanum"`
+	Description    *string  `json:"description" validate:"omitempty,max=500"`
+	VenueID        *string  `json:"venue_id" validate:"omitempty,uuid"`
+	CourtIDs       []string `json:"court_ids" validate:"omitempty,dive,uuid"`
+	DiscountType   string   `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue  float64  `json:"discount_value" validate:"required,gt=0"`
+	MinSpend       float64  `json:"min_spend" validate:"omitempty,min=0"`
+	MaxUses        *int     `json:"max_uses" validate:"omitempty,min=1"`
+	MaxUsesPerUser *int     `json:"max_uses_per_user" validate:"omitempty,min=1"`
+	StartsAt       string   `json:"starts_at" validate:"required,datetime"`
+	EndsAt         string   `json:"ends_at" validate:"required,datetime"`
+}
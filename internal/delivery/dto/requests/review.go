@@ -0,0 +1,8 @@
+package requests
+
+type CreatePlayerReviewRequest struct {
+	SessionID  string `json:"session_id" validate:"required,uuid"`
+	ReviewedID string `json:"reviewed_id" validate:"required,uuid"`
+	Rating     int    `json:"rating" validate:"required,min=1,max=5"`
+	Comment    string `json:"comment" validate:"omitempty,max=1000"`
+}
@@ -0,0 +1,13 @@
+package requests
+
+// CreateScheduleOverrideRequest represents a date-specific change to a venue's opening hours.
+// Closed with no times closes the whole day, closed with times closes that window, and
+// times without closed are the day's special opening hours. CourtID limits it to one court.
+type CreateScheduleOverrideRequest struct {
+	CourtID   *string `json:"court_id" validate:"omitempty,uuid"`
+	Date      string  `json:"date" validate:"required,datetime"`
+	Closed    bool    `json:"closed"`
+	StartTime *string `json:"start_time" validate:"omitempty,datetime"`
+	EndTime   *string `json:"end_time" validate:"omitempty,datetime"`
+	Reason    *string `json:"reason" validate:"omitempty,max=255"`
+}
@@ -14,6 +14,18 @@ type CreateSessionRequest struct {
 	CancellationDeadlineHours int      `json:"cancellation_deadline_hours" validate:"required_if=AllowCancellation true,min=0"`
 	IsPublic                  bool     `json:"is_public"`
 	Rules                     []string `json:"rules" validate:"omitempty,dive,min=1"`
+	CourtIDs                  []string `json:"court_ids" validate:"omitempty,dive,uuid"`
+	BookCourts                bool     `json:"book_courts"`
+	// Recurrence repeats the session from SessionDate onwards, one play session per occurrence
+	Recurrence *RecurrenceRequest `json:"recurrence"`
+}
+
+// RecurrenceRequest describes a weekly schedule ending on a date or after a number of occurrences
+type RecurrenceRequest struct {
+	Weekdays      []string `json:"weekdays" validate:"required,min=1,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	IntervalWeeks int      `json:"interval_weeks" validate:"omitempty,min=1"`
+	Until         string   `json:"until" validate:"required_without=Count"`
+	Count         int      `json:"count" validate:"required_without=Until,omitempty,min=1,max=52"`
 }
 
 type UpdateSessionRequest struct {
@@ -27,10 +39,14 @@ type UpdateSessionRequest struct {
 	CancellationDeadlineHours int      `json:"cancellation_deadline_hours" validate:"omitempty,min=0"`
 	IsPublic                  bool     `json:"is_public"`
 	Rules                     []string `json:"rules" validate:"omitempty,dive,min=1"`
+	// Scope applies the change to this occurrence only or to it and every later one
+	Scope string `json:"scope" validate:"omitempty,oneof=this following"`
 }
 
 type JoinSessionRequest struct {
 	Message string `json:"message"` // Optional message for the host
+	// JoinSeries also enrolls the player in every later occurrence of a recurring session
+	JoinSeries bool `json:"join_series"`
 }
 
 type AddSessionRuleRequest struct {
@@ -17,6 +17,9 @@ type CreateVenueRequest struct {
 	Facilities  []Facility  `json:"facilities" validate:"required"`
 	Latitude    float64     `json:"latitude"`
 	Longitude   float64     `json:"longitude"`
+
+	BookingHoldMinutes int                `json:"booking_hold_minutes" validate:"omitempty,min=1"`
+	CancellationPolicy []CancellationTier `json:"cancellation_policy" validate:"omitempty,dive"`
 }
 
 type Facility struct {
@@ -30,6 +33,11 @@ type OpenRange struct {
 	CloseTime time.Time `json:"close_time"`
 }
 
+type CancellationTier struct {
+	HoursBefore   int `json:"hours_before" validate:"min=0"`
+	RefundPercent int `json:"refund_percent" validate:"min=0,max=100"`
+}
+
 type Rule struct {
 	Rule string `json:"rule"`
 }
@@ -48,6 +56,9 @@ type UpdateVenueRequest struct {
 	Facilities  []Facility  `json:"facilities"`
 	Latitude    float64     `json:"latitude"`
 	Longitude   float64     `json:"longitude"`
+
+	BookingHoldMinutes int                `json:"booking_hold_minutes" validate:"omitempty,min=1"`
+	CancellationPolicy []CancellationTier `json:"cancellation_policy" validate:"omitempty,dive"`
 }
 
 // type CreateCourtRequest struct {
@@ -68,3 +79,12 @@ type AddReviewRequest struct {
 	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
 	Comment string `json:"comment"`
 }
+
+// GeoQuery represents the "near me" and map view filters of a venue list or search.
+// BBox is a bounding box given as "min_lat,min_lng,max_lat,max_lng".
+type GeoQuery struct {
+	Latitude  *float64 `json:"lat"`
+	Longitude *float64 `json:"lng"`
+	RadiusKm  float64  `json:"radius_km"`
+	BBox      string   `json:"bbox"`
+}
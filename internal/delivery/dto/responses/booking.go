@@ -19,14 +19,71 @@ type BookingResponse struct {
 	EndTime       string           `json:"end_time"`
 	Duration      string           `json:"duration"`
 	TotalAmount   float64          `json:"total_amount"`
+	Discount      float64          `json:"discount_amount,omitempty"`
+	PromotionID   string           `json:"promotion_id,omitempty"`
 	Status        string           `json:"status"`
 	Notes         string           `json:"notes,omitempty"`
+	SessionID     string           `json:"session_id,omitempty"`
+	SeriesID      string           `json:"series_id,omitempty"`
+	OrderID       string           `json:"order_id,omitempty"`
 	CreatedAt     string           `json:"created_at"`
 	UpdatedAt     string           `json:"updated_at"`
 	CancelledAt   string           `json:"cancelled_at,omitempty"`
+	HoldExpiresAt string           `json:"hold_expires_at,omitempty"`
 	Payment       *PaymentResponse `json:"payment,omitempty"`
 }
 
+// CancelBookingResponse represents the outcome of cancelling a booking
+type CancelBookingResponse struct {
+	BookingID     string  `json:"booking_id"`
+	Status        string  `json:"status"`
+	RefundPercent int     `json:"refund_percent"`
+	RefundAmount  float64 `json:"refund_amount"`
+	RefundID      string  `json:"refund_id,omitempty"`
+}
+
+// RecurringBookingResponse represents the outcome of booking a court every week
+type RecurringBookingResponse struct {
+	SeriesID    string                `json:"series_id"`
+	Bookings    []BookingResponse     `json:"bookings"`
+	Conflicts   []BookingDateConflict `json:"conflicts"`
+	TotalAmount float64               `json:"total_amount"`
+}
+
+// BookingDateConflict explains why a single slot of a recurring booking or order could not be booked
+type BookingDateConflict struct {
+	CourtID   string `json:"court_id,omitempty"`
+	Date      string `json:"date"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// BookingOrderResponse represents the bookings created by a checkout
+type BookingOrderResponse struct {
+	ID          string            `json:"id"`
+	Bookings    []BookingResponse `json:"bookings"`
+	TotalAmount float64           `json:"total_amount"`
+	CreatedAt   string            `json:"created_at"`
+}
+
+// OrderPaymentResponse represents the single payment covering every booking of an order
+type OrderPaymentResponse struct {
+	OrderID       string            `json:"order_id"`
+	Amount        float64           `json:"amount"`
+	Status        string            `json:"status"`
+	PaymentMethod string            `json:"payment_method"`
+	TransactionID string            `json:"transaction_id,omitempty"`
+	CheckoutURL   string            `json:"checkout_url,omitempty"`
+	Payments      []PaymentResponse `json:"payments"`
+}
+
+// BookingConflictsErrorResponse is returned when a recurring booking or order is rejected over its conflicts
+type BookingConflictsErrorResponse struct {
+	ErrorResponse
+	Conflicts []BookingDateConflict `json:"conflicts"`
+}
+
 // PaymentResponse represents the response for a booking payment
 type PaymentResponse struct {
 	ID            string  `json:"id"`
@@ -34,6 +91,7 @@ type PaymentResponse struct {
 	Status        string  `json:"status"`
 	PaymentMethod string  `json:"payment_method"`
 	TransactionID string  `json:"transaction_id,omitempty"`
+	CheckoutURL   string  `json:"checkout_url,omitempty"`
 	CreatedAt     string  `json:"created_at"`
 	UpdatedAt     string  `json:"updated_at"`
 }
@@ -46,12 +104,78 @@ type CourtAvailabilityResponse struct {
 	Available bool          `json:"available"`
 	TimeSlots []TimeSlot    `json:"time_slots"`
 	Conflicts []BookingSlot `json:"conflicts,omitempty"`
+
+	// Price of the requested time range, broken down by the pricing rules it crosses
+	Price          float64                `json:"price"`
+	PriceBreakdown []PriceSegmentResponse `json:"price_breakdown"`
 }
 
 // TimeSlot represents an available time slot
 type TimeSlot struct {
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	StartTime string  `json:"start_time"`
+	EndTime   string  `json:"end_time"`
+	Price     float64 `json:"price"`
+}
+
+// VenueAvailabilityResponse is the slot grid of every court of a venue, day by day
+type VenueAvailabilityResponse struct {
+	VenueID   string                    `json:"venue_id"`
+	VenueName string                    `json:"venue_name"`
+	Days      []DayAvailabilityResponse `json:"days"`
+}
+
+// DayAvailabilityResponse is the slot grid of a single day. Slots run from the venue's
+// opening to closing time, so a closed day has none.
+type DayAvailabilityResponse struct {
+	Date      string                      `json:"date"`
+	Open      bool                        `json:"open"`
+	OpenTime  string                      `json:"open_time,omitempty"`
+	CloseTime string                      `json:"close_time,omitempty"`
+	Reason    string                      `json:"reason,omitempty"`
+	Courts    []CourtAvailabilityGridItem `json:"courts"`
+}
+
+// CourtAvailabilityGridItem is a court's row in the availability grid
+type CourtAvailabilityGridItem struct {
+	CourtID   string     `json:"court_id"`
+	CourtName string     `json:"court_name"`
+	Slots     []GridSlot `json:"slots"`
+}
+
+// GridSlot is a half-hour slot of the availability grid. Status is free, booked,
+// maintenance or closed.
+type GridSlot struct {
+	StartTime string  `json:"start_time"`
+	EndTime   string  `json:"end_time"`
+	Status    string  `json:"status"`
+	Price     float64 `json:"price"`
+}
+
+// AvailabilitySearchResponse lists the venues with free courts for a searched time
+type AvailabilitySearchResponse struct {
+	Venues []AvailableVenueResponse `json:"venues"`
+	Total  int                      `json:"total"`
+}
+
+// AvailableVenueResponse is a venue with at least one court free for the searched time
+type AvailableVenueResponse struct {
+	VenueID     string                   `json:"venue_id"`
+	VenueName   string                   `json:"venue_name"`
+	Address     string                   `json:"address"`
+	Location    string                   `json:"location"`
+	Latitude    float64                  `json:"latitude"`
+	Longitude   float64                  `json:"longitude"`
+	Rating      float64                  `json:"rating"`
+	DistanceKm  *float64                 `json:"distance_km,omitempty"`
+	LowestPrice float64                  `json:"lowest_price"`
+	Courts      []AvailableCourtResponse `json:"courts"`
+}
+
+// AvailableCourtResponse is a court with the slots it is free for
+type AvailableCourtResponse struct {
+	CourtID   string     `json:"court_id"`
+	CourtName string     `json:"court_name"`
+	Slots     []TimeSlot `json:"slots"`
 }
 
 // BookingSlot represents a conflicting booking slot
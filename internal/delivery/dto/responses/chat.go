@@ -14,6 +14,8 @@ type ChatMassageResponse struct {
 	Message       string           `json:"message"`
 	Timestamp     time.Time        `json:"timestamp"`
 	EditTimeStamp time.Time        `json:"edit_timestamp"`
+	// ClientMessageID is the id the sender gave the message, if any
+	ClientMessageID *string `json:"client_message_id,omitempty"`
 }
 
 type BoardCastMessageResponse struct {
@@ -21,6 +23,13 @@ type BoardCastMessageResponse struct {
 	Data         interface{} `json:"data,omitempty"`
 }
 
+// WebSocketTicketResponse is a short-lived ticket for opening a chat's WebSocket from a
+// browser, passed as /ws/:chat_id?ticket=
+type WebSocketTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type ChatListResponse struct {
 	Chats []ChatResponse `json:"chats"`
 }
@@ -0,0 +1,35 @@
+package responses
+
+type ConnectionUserResponse struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	AvatarURL string `json:"avatar_url"`
+	PlayLevel string `json:"play_level"`
+}
+
+type ConnectionResponse struct {
+	ID        string                 `json:"id"`
+	Status    string                 `json:"status"`
+	Direction string                 `json:"direction,omitempty"`
+	User      ConnectionUserResponse `json:"user"`
+	CreatedAt string                 `json:"created_at"`
+}
+
+type ConnectionListResponse struct {
+	Connections []ConnectionResponse `json:"connections"`
+	Total       int                  `json:"total"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+type ConnectionRequestsResponse struct {
+	Incoming []ConnectionResponse `json:"incoming"`
+	Outgoing []ConnectionResponse `json:"outgoing"`
+}
+
+type PartnerSuggestionResponse struct {
+	User             ConnectionUserResponse `json:"user"`
+	SessionsTogether int                    `json:"sessions_together"`
+	LastPlayedAt     string                 `json:"last_played_at"`
+}
@@ -0,0 +1,12 @@
+package responses
+
+// MaintenanceWindowResponse represents a scheduled maintenance of a court
+type MaintenanceWindowResponse struct {
+	ID        string `json:"id"`
+	CourtID   string `json:"court_id"`
+	CourtName string `json:"court_name,omitempty"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package responses
+
+// PricingRuleResponse represents a venue pricing rule
+type PricingRuleResponse struct {
+	ID           string   `json:"id"`
+	VenueID      string   `json:"venue_id"`
+	CourtID      string   `json:"court_id,omitempty"`
+	Name         string   `json:"name"`
+	Weekdays     []string `json:"weekdays"`
+	HolidaysOnly bool     `json:"holidays_only"`
+	StartTime    string   `json:"start_time"`
+	EndTime      string   `json:"end_time"`
+	StartDate    string   `json:"start_date,omitempty"`
+	EndDate      string   `json:"end_date,omitempty"`
+	PricePerHour float64  `json:"price_per_hour"`
+	Priority     int      `json:"priority"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// HolidayResponse represents a date on a venue's holiday calendar
+type HolidayResponse struct {
+	ID   string `json:"id"`
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// PriceSegmentResponse represents part of a booking charged at a single price per hour
+type PriceSegmentResponse struct {
+	StartTime    string  `json:"start_time"`
+	EndTime      string  `json:"end_time"`
+	PricePerHour float64 `json:"price_per_hour"`
+	Amount       float64 `json:"amount"`
+	RuleID       string  `json:"rule_id,omitempty"`
+}
@@ -0,0 +1,20 @@
+package responses
+
+// PromotionResponse represents a discount code
+type PromotionResponse struct {
+	ID             string   `json:"id"`
+	Code           string   `json:"code"`
+	Description    string   `json:"description,omitempty"`
+	VenueID        string   `json:"venue_id,omitempty"`
+	CourtIDs       []string `json:"court_ids"`
+	DiscountType   string   `json:"discount_type"`
+	DiscountValue  float64  `json:"discount_value"`
+	MinSpend       float64  `json:"min_spend"`
+	MaxUses        *int     `json:"max_uses,omitempty"`
+	MaxUsesPerUser *int     `json:"max_uses_per_user,omitempty"`
+	UsesCount      int      `json:"uses_count"`
+	StartsAt       string   `json:"starts_at"`
+	EndsAt         string   `json:"ends_at"`
+	Active         bool     `json:"active"`
+	CreatedAt      string   `json:"created_at"`
+}
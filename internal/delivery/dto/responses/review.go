@@ -0,0 +1,35 @@
+package responses
+
+type PlayerReviewResponse struct {
+	ID           string                   `json:"id"`
+	SessionID    string                   `json:"session_id"`
+	SessionTitle string                   `json:"session_title"`
+	SessionDate  string                   `json:"session_date"`
+	Rating       int                      `json:"rating"`
+	Comment      string                   `json:"comment"`
+	CreatedAt    string                   `json:"created_at"`
+	Reviewer     PlayerReviewUserResponse `json:"reviewer"`
+	Reviewed     PlayerReviewUserResponse `json:"reviewed"`
+}
+
+type PlayerReviewUserResponse struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type PlayerReviewListResponse struct {
+	Reviews       []PlayerReviewResponse `json:"reviews"`
+	AverageRating float64                `json:"average_rating"`
+	Total         int                    `json:"total"`
+	Limit         int                    `json:"limit"`
+	Offset        int                    `json:"offset"`
+}
+
+// ReviewablePlayerResponse is a co-player the caller can still rate for a session
+type ReviewablePlayerResponse struct {
+	UserID      string `json:"user_id"`
+	Name        string `json:"name"`
+	PlayerLevel string `json:"player_level"`
+}
@@ -0,0 +1,16 @@
+package responses
+
+// ScheduleOverrideResponse represents a date-specific change to a venue's opening hours
+type ScheduleOverrideResponse struct {
+	ID        string `json:"id"`
+	VenueID   string `json:"venue_id"`
+	CourtID   string `json:"court_id,omitempty"`
+	Date      string `json:"date"`
+	Closed    bool   `json:"closed"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt string `json:"created_at"`
+	// AffectedBookings are active bookings the override no longer allows, for the owner to follow up on
+	AffectedBookings []BookingResponse `json:"affected_bookings,omitempty"`
+}
@@ -17,31 +17,59 @@ type SessionRuleResponse struct {
 	CreatedAt string `json:"created_at"`
 }
 
+type SessionCourtResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type SessionResponse struct {
-	ID                        string                `json:"id"`
-	Title                     string                `json:"title"`
-	Description               string                `json:"description"`
-	VenueName                 string                `json:"venue_name"`
-	VenueLocation             string                `json:"venue_location"`
-	HostName                  string                `json:"host_name"`
-	HostLevel                 string                `json:"host_level"`
-	SessionDate               string                `json:"session_date"`
-	StartTime                 string                `json:"start_time"`
-	EndTime                   string                `json:"end_time"`
-	PlayerLevel               string                `json:"player_level"`
-	MaxParticipants           int                   `json:"max_participants"`
-	CostPerPerson             float64               `json:"cost_per_person"`
-	Status                    string                `json:"status"`
-	AllowCancellation         bool                  `json:"allow_cancellation"`
-	CancellationDeadlineHours *int                  `json:"cancellation_deadline_hours,omitempty"`
-	IsPublic                  bool                  `json:"is_public"`
-	ConfirmedPlayers          int                   `json:"confirmed_players"`
-	PendingPlayers            int                   `json:"pending_players"`
-	Participants              []ParticipantResponse `json:"participants,"`
-	Rules                     []SessionRuleResponse `json:"rules,"`
-	CreatedAt                 string                `json:"created_at"`
-	UpdatedAt                 string                `json:"updated_at"`
-	JoinStatus                *string               `json:"join_status"`
+	ID                        string                 `json:"id"`
+	Title                     string                 `json:"title"`
+	Description               string                 `json:"description"`
+	VenueName                 string                 `json:"venue_name"`
+	VenueLocation             string                 `json:"venue_location"`
+	HostName                  string                 `json:"host_name"`
+	HostLevel                 string                 `json:"host_level"`
+	SessionDate               string                 `json:"session_date"`
+	StartTime                 string                 `json:"start_time"`
+	EndTime                   string                 `json:"end_time"`
+	PlayerLevel               string                 `json:"player_level"`
+	MaxParticipants           int                    `json:"max_participants"`
+	CostPerPerson             float64                `json:"cost_per_person"`
+	Status                    string                 `json:"status"`
+	AllowCancellation         bool                   `json:"allow_cancellation"`
+	CancellationDeadlineHours *int                   `json:"cancellation_deadline_hours,omitempty"`
+	IsPublic                  bool                   `json:"is_public"`
+	ConfirmedPlayers          int                    `json:"confirmed_players"`
+	PendingPlayers            int                    `json:"pending_players"`
+	WaitlistedPlayers         int                    `json:"waitlisted_players"`
+	Participants              []ParticipantResponse  `json:"participants,"`
+	Rules                     []SessionRuleResponse  `json:"rules,"`
+	Courts                    []SessionCourtResponse `json:"courts"`
+	CreatedAt                 string                 `json:"created_at"`
+	UpdatedAt                 string                 `json:"updated_at"`
+	JoinStatus                *string                `json:"join_status"`
+	WaitlistPosition          *int                   `json:"waitlist_position,omitempty"`
+	SeriesID                  string                 `json:"series_id,omitempty"`
+	Series                    *SessionSeriesResponse `json:"series,omitempty"`
+}
+
+// SessionSeriesResponse describes the recurrence a session was created with
+type SessionSeriesResponse struct {
+	ID              string   `json:"id"`
+	Weekdays        []string `json:"weekdays"`
+	IntervalWeeks   int      `json:"interval_weeks"`
+	Until           string   `json:"until,omitempty"`
+	Count           *int     `json:"count,omitempty"`
+	OccurrenceDates []string `json:"occurrence_dates"`
+}
+
+// JoinSessionResponse tells a player whether they got a spot or joined the waitlist
+type JoinSessionResponse struct {
+	Status           string `json:"status"`
+	WaitlistPosition *int   `json:"waitlist_position,omitempty"`
+	// EnrolledSessions counts the later occurrences joined through join_series
+	EnrolledSessions int `json:"enrolled_sessions,omitempty"`
 }
 
 type SessionListResponse struct {
@@ -3,11 +3,14 @@ package responses
 import "time"
 
 type CourtResponse struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	PricePerHour float64 `json:"price_per_hour"`
-	Status       string  `json:"status"`
+	ID            string  `json:"id"`
+	VenueID       string  `json:"venue_id,omitempty"`
+	VenueName     string  `json:"venue_name,omitempty"`
+	VenueLocation string  `json:"venue_location,omitempty"`
+	Name          string  `json:"name"`
+	Description   string  `json:"description"`
+	PricePerHour  float64 `json:"price_per_hour"`
+	Status        string  `json:"status"`
 }
 
 type VenueResponse struct {
@@ -28,6 +31,17 @@ type VenueResponse struct {
 	Rules        []RuleResponse      `json:"rules"`
 	Latitude     float64             `json:"latitude"`
 	Longitude    float64             `json:"longitude"`
+
+	BookingHoldMinutes int                        `json:"booking_hold_minutes"`
+	CancellationPolicy []CancellationTierResponse `json:"cancellation_policy"`
+
+	// DistanceKm is set when searching around a point
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+}
+
+type CancellationTierResponse struct {
+	HoursBefore   int `json:"hours_before"`
+	RefundPercent int `json:"refund_percent"`
 }
 
 type OpenRangeResponse struct {
@@ -47,8 +61,11 @@ type VenueResponseDTO struct {
 }
 
 type ListVenueResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Latitude   float64  `json:"latitude"`
+	Longitude  float64  `json:"longitude"`
+	DistanceKm *float64 `json:"distance_km,omitempty"`
 }
 
 type ReviewResponse struct {
@@ -18,6 +18,8 @@ var (
 	ErrInvalidUserID = errors.New("invalid user ID in token")
 )
 
+var jwtSecret = []byte("your-jwt-secret")
+
 func AuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
@@ -34,30 +36,10 @@ func AuthRequired() fiber.Handler {
 			})
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fiber.ErrUnauthorized
-			}
-			return []byte("your-jwt-secret"), nil
-		})
-
-		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidToken.Error(),
-			})
-		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidClaims.Error(),
-			})
-		}
-
-		userID, err := uuid.Parse(claims["user_id"].(string))
+		userID, err := ParseToken(tokenString)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidUserID.Error(),
+				"error": err.Error(),
 			})
 		}
 
@@ -68,6 +50,51 @@ func AuthRequired() fiber.Handler {
 	}
 }
 
+// ParseToken validates a login token and returns the user it was issued to. WebSocket
+// tickets are signed with the same secret but are rejected here, so a ticket leaked
+// through a URL cannot be used as a bearer token.
+func ParseToken(tokenString string) (uuid.UUID, error) {
+	claims, err := parseClaims(tokenString)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, ok := claims["typ"]; ok {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	return userIDClaim(claims)
+}
+
+func parseClaims(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fiber.ErrUnauthorized
+		}
+		return jwtSecret, nil
+	})
+
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+
+	return claims, nil
+}
+
+func userIDClaim(claims jwt.MapClaims) (uuid.UUID, error) {
+	value, _ := claims["user_id"].(string)
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, ErrInvalidUserID
+	}
+	return userID, nil
+}
+
 // GetUserID gets the user ID from the Fiber context
 func GetUserID(c *fiber.Ctx) (uuid.UUID, error) {
 	userID, ok := c.Locals("userID").(uuid.UUID)
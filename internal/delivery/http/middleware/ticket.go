@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// WebSocketTicketDuration is how long a WebSocket ticket can be used to connect. Browsers
+// cannot set headers on a WebSocket handshake, so the ticket travels in the URL and is
+// kept short-lived.
+const WebSocketTicketDuration = 30 * time.Second
+
+const webSocketTicketType = "ws_ticket"
+
+var ErrInvalidTicket = errors.New("invalid websocket ticket")
+
+// NewWebSocketTicket issues a ticket that lets a user open the WebSocket of one chat
+func NewWebSocketTicket(userID, chatID uuid.UUID) (string, time.Time, error) {
+	expiresAt := time.Now().Add(WebSocketTicketDuration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"typ":     webSocketTicketType,
+		"user_id": userID.String(),
+		"chat_id": chatID.String(),
+		"exp":     expiresAt.Unix(),
+		"iat":     time.Now().Unix(),
+	})
+
+	ticket, err := token.SignedString(jwtSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return ticket, expiresAt, nil
+}
+
+// ParseWebSocketTicket validates a ticket for a chat and returns the user it was issued to
+func ParseWebSocketTicket(ticket string, chatID uuid.UUID) (uuid.UUID, error) {
+	claims, err := parseClaims(ticket)
+	if err != nil {
+		return uuid.Nil, ErrInvalidTicket
+	}
+
+	if typ, _ := claims["typ"].(string); typ != webSocketTicketType {
+		return uuid.Nil, ErrInvalidTicket
+	}
+	if ticketChat, _ := claims["chat_id"].(string); ticketChat != chatID.String() {
+		return uuid.Nil, ErrInvalidTicket
+	}
+
+	return userIDClaim(claims)
+}
@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
@@ -27,10 +30,16 @@ func (h *BookingHandler) SetupBookingRoutes(app *fiber.App) {
 
 	// Public routes
 	bookings.Get("/availability", h.CheckAvailability)
+	bookings.Get("/availability/venue", h.GetVenueAvailability)
+	bookings.Get("/availability/search", h.SearchAvailability)
 
 	// Protected routes
 	bookings.Use(middleware.AuthRequired())
 	bookings.Post("/", h.CreateBooking)
+	bookings.Post("/recurring", h.CreateRecurringBooking)
+	bookings.Post("/series/:id/cancel", h.CancelBookingSeries)
+	bookings.Post("/orders", h.CreateBookingOrder)
+	bookings.Post("/orders/:id/payment", h.CreateOrderPayment)
 	bookings.Get("/", h.ListBookings)
 	bookings.Get("/:id", h.GetBooking)
 	bookings.Put("/:id", h.UpdateBooking)
@@ -58,9 +67,7 @@ func (h *BookingHandler) CreateBooking(c *fiber.Ctx) error {
 
 	booking, err := h.bookingUseCase.CreateBooking(c.Context(), userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return h.handleError(c, err)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
@@ -69,6 +76,111 @@ func (h *BookingHandler) CreateBooking(c *fiber.Ctx) error {
 	})
 }
 
+// CreateRecurringBooking handles booking a court on the same weekdays across a date range
+func (h *BookingHandler) CreateRecurringBooking(c *fiber.Ctx) error {
+	var req requests.CreateRecurringBookingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.bookingUseCase.CreateRecurringBooking(c.Context(), userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Recurring booking created successfully",
+		Data:    result,
+	})
+}
+
+// CancelBookingSeries handles cancelling the remaining bookings of a recurring booking
+func (h *BookingHandler) CancelBookingSeries(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid series ID",
+			Code:        "INVALID_ID",
+			Description: "The provided series ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.bookingUseCase.CancelBookingSeries(c.Context(), id, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Recurring booking cancelled successfully",
+		Data:    result,
+	})
+}
+
+// CreateBookingOrder handles checking out several court slots in one order
+func (h *BookingHandler) CreateBookingOrder(c *fiber.Ctx) error {
+	var req requests.CreateBookingOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.bookingUseCase.CreateBookingOrder(c.Context(), userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Booking order created successfully",
+		Data:    result,
+	})
+}
+
+// CreateOrderPayment handles paying for every booking of an order at once
+func (h *BookingHandler) CreateOrderPayment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid order ID",
+			Code:        "INVALID_ID",
+			Description: "The provided order ID is not in a valid format",
+		})
+	}
+
+	var req requests.CreatePaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.bookingUseCase.CreateOrderPayment(c.Context(), id, userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Payment created successfully",
+		Data:    result,
+	})
+}
+
 // GetBooking handles retrieving a single booking
 func (h *BookingHandler) GetBooking(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
@@ -137,11 +249,11 @@ func (h *BookingHandler) UpdateBooking(c *fiber.Ctx) error {
 		})
 	}
 
-	booking, err := h.bookingUseCase.UpdateBooking(c.Context(), id, req)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	booking, err := h.bookingUseCase.UpdateBooking(c.Context(), id, userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return h.handleError(c, err)
 	}
 
 	return c.JSON(responses.SuccessResponse{
@@ -163,7 +275,8 @@ func (h *BookingHandler) CancelBooking(c *fiber.Ctx) error {
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.bookingUseCase.CancelBooking(c.Context(), id, userID); err != nil {
+	result, err := h.bookingUseCase.CancelBooking(c.Context(), id, userID)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -171,6 +284,7 @@ func (h *BookingHandler) CancelBooking(c *fiber.Ctx) error {
 
 	return c.JSON(responses.SuccessResponse{
 		Message: "Booking cancelled successfully",
+		Data:    result,
 	})
 }
 
@@ -212,6 +326,61 @@ func (h *BookingHandler) CheckAvailability(c *fiber.Ctx) error {
 	})
 }
 
+// GetVenueAvailability returns the slot grid of every court of a venue for a date and the days after it
+func (h *BookingHandler) GetVenueAvailability(c *fiber.Ctx) error {
+	req := requests.VenueAvailabilityRequest{
+		VenueID: c.Query("venue_id"),
+		Date:    c.Query("date"),
+		Days:    c.QueryInt("days", 1),
+	}
+
+	availability, err := h.bookingUseCase.GetVenueAvailability(c.Context(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: availability,
+	})
+}
+
+// SearchAvailability finds venues with a court free at a time, e.g.
+// ?date=2024-11-16&from=18:00&to=20:00&duration=60&lat=13.75&lng=100.5&radius_km=5
+func (h *BookingHandler) SearchAvailability(c *fiber.Ctx) error {
+	req := requests.SearchAvailabilityRequest{
+		Date:     c.Query("date"),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Duration: c.QueryInt("duration", 0),
+		RadiusKm: c.QueryFloat("radius_km", 0),
+		MaxPrice: c.QueryFloat("max_price", 0),
+		SortBy:   c.Query("sort_by"),
+		Limit:    c.QueryInt("limit", 0),
+		Offset:   c.QueryInt("offset", 0),
+	}
+
+	var err error
+	if req.Latitude, err = queryCoordinate(c, "lat"); err != nil {
+		return h.handleError(c, fmt.Errorf("%w: %v", booking.ErrValidation, err))
+	}
+	if req.Longitude, err = queryCoordinate(c, "lng"); err != nil {
+		return h.handleError(c, fmt.Errorf("%w: %v", booking.ErrValidation, err))
+	}
+
+	if facilities := c.Query("facilities"); facilities != "" {
+		req.Facilities = strings.Split(facilities, ",")
+	}
+
+	result, err := h.bookingUseCase.SearchAvailability(c.Context(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
 // get payment for booking
 func (h *BookingHandler) GetPayment(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
@@ -257,9 +426,7 @@ func (h *BookingHandler) CreatePayment(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	payment, err := h.bookingUseCase.CreatePayment(c.Context(), bookingID, userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return h.handleError(c, err)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
@@ -289,13 +456,11 @@ func (h *BookingHandler) UpdatePayment(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	payment, err := h.bookingUseCase.UpdatePayment(c.Context(), bookingID, userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Payment created successfully",
+	return c.JSON(responses.SuccessResponse{
+		Message: "Payment updated successfully",
 		Data:    payment,
 	})
 }
@@ -316,38 +481,69 @@ func (h *BookingHandler) ChangeCourtStatus(c *fiber.Ctx) error {
 
 // handleError centralizes error handling
 func (h *BookingHandler) handleError(c *fiber.Ctx, err error) error {
+	// Recurring bookings report every conflicting date, not just the first one
+	var conflictsErr *booking.ConflictsError
+	if errors.As(err, &conflictsErr) {
+		return c.Status(fiber.StatusConflict).JSON(responses.BookingConflictsErrorResponse{
+			ErrorResponse: responses.ErrorResponse{
+				Error:       "Booking conflict",
+				Code:        "BOOKING_CONFLICT",
+				Description: err.Error(),
+			},
+			Conflicts: conflictsErr.Conflicts,
+		})
+	}
+
 	// Add specific error types
 	switch {
-	case err == booking.ErrBookingNotFound:
+	case errors.Is(err, booking.ErrBookingNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
+			Error:       "Booking not found",
+			Code:        "BOOKING_NOT_FOUND",
+			Description: err.Error(),
+		})
+	case errors.Is(err, booking.ErrVenueNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
-			Error: "Booking not found",
-			Code:  "BOOKING_NOT_FOUND",
+			Error:       "Venue not found",
+			Code:        "VENUE_NOT_FOUND",
+			Description: err.Error(),
 		})
-	case err == booking.ErrUnauthorized:
+	case errors.Is(err, booking.ErrUnauthorized):
 		return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
-			Error: "Unauthorized",
-			Code:  "UNAUTHORIZED",
+			Error:       "Unauthorized",
+			Code:        "UNAUTHORIZED",
+			Description: err.Error(),
 		})
-	case err == booking.ErrValidation:
+	case errors.Is(err, booking.ErrValidation):
 		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-			Error: "Validation error",
-			Code:  "VALIDATION_ERROR",
+			Error:       "Validation error",
+			Code:        "VALIDATION_ERROR",
+			Description: err.Error(),
 		})
-	case err == booking.ErrBookingConflict:
+	case errors.Is(err, booking.ErrBookingConflict):
 		return c.Status(fiber.StatusConflict).JSON(responses.ErrorResponse{
-			Error: "Booking conflict",
-			Code:  "BOOKING_CONFLICT",
+			Error:       "Booking conflict",
+			Code:        "BOOKING_CONFLICT",
+			Description: err.Error(),
 		})
-	case err == booking.ErrPaymentRequired:
+	case errors.Is(err, booking.ErrInvalidPromotion):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(responses.ErrorResponse{
+			Error:       "Invalid promotion code",
+			Code:        "INVALID_PROMOTION",
+			Description: err.Error(),
+		})
+	case errors.Is(err, booking.ErrPaymentRequired):
 		return c.Status(fiber.StatusPaymentRequired).JSON(responses.ErrorResponse{
-			Error: "Payment required",
-			Code:  "PAYMENT_REQUIRED",
+			Error:       "Payment required",
+			Code:        "PAYMENT_REQUIRED",
+			Description: err.Error(),
 		})
 	default:
 		// Log the error here
 		return c.Status(fiber.StatusInternalServerError).JSON(responses.ErrorResponse{
-			Error: "Internal server error",
-			Code:  "INTERNAL_ERROR",
+			Error:       "Internal server error",
+			Code:        "INTERNAL_ERROR",
+			Description: err.Error(),
 		})
 	}
 }
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/booking"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// conflictingBookingUseCase fails every booking the way the repository does when a
+// concurrent booking took the slot first
+type conflictingBookingUseCase struct {
+	booking.UseCase
+}
+
+func (conflictingBookingUseCase) CreateBooking(ctx context.Context, userID uuid.UUID, req requests.CreateBookingRequest) (*responses.BookingResponse, error) {
+	return nil, fmt.Errorf("failed to create booking: %w", fmt.Errorf("%w: court is not available for the requested time", interfaces.ErrBookingConflict))
+}
+
+func TestCreateBookingConflictReturns409(t *testing.T) {
+	h := NewBookingHandler(conflictingBookingUseCase{})
+
+	app := fiber.New()
+	app.Post("/api/bookings", func(c *fiber.Ctx) error {
+		c.Locals("userID", uuid.New())
+		return c.Next()
+	}, h.CreateBooking)
+
+	body := `{"court_id":"` + uuid.NewString() + `","date":"2030-01-07","start_time":"10:00","end_time":"12:00"}`
+	req := httptest.NewRequest(fiber.MethodPost, "/api/bookings", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Fatalf("expected status %d, got %d", fiber.StatusConflict, resp.StatusCode)
+	}
+
+	var errResp responses.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if errResp.Code != "BOOKING_CONFLICT" {
+		t.Fatalf("expected code BOOKING_CONFLICT, got %q", errResp.Code)
+	}
+}
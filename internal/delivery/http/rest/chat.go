@@ -5,8 +5,9 @@ import (
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/delivery/http/middleware"
 	"badbuddy/internal/delivery/http/ws"
+	"badbuddy/internal/infrastructure/pubsub"
 	"badbuddy/internal/usecase/chat"
-	"encoding/json"
+	"log"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,13 +18,13 @@ import (
 
 type ChatHandler struct {
 	chatUseCase chat.UseCase
-	chatHub     *ws.ChatHub
+	pubsub      pubsub.PubSub
 }
 
-func NewChatHandler(chatUseCase chat.UseCase, chatHub *ws.ChatHub) *ChatHandler {
+func NewChatHandler(chatUseCase chat.UseCase, ps pubsub.PubSub) *ChatHandler {
 	return &ChatHandler{
 		chatUseCase: chatUseCase,
-		chatHub:     chatHub,
+		pubsub:      ps,
 	}
 }
 
@@ -41,6 +42,7 @@ func (h *ChatHandler) SetupChatRoutes(app *fiber.App) {
 	chat.Put("/:chatID/messages/:messageID", h.UpdateMessage)
 
 	chat.Get("/:chatID/users", h.GetUsersInChat)
+	chat.Post("/:chatID/ws-ticket", h.CreateWebSocketTicket)
 
 	chat.Get("direct/:userID/messages", h.GetDirectChat)
 	chat.Get("session/:sessionID/messages", h.GetChatMessageOfSession)
@@ -73,11 +75,7 @@ func (h *ChatHandler) GetChatMessage(c *fiber.Ctx) error {
 		return h.handleError(c, err)
 	}
 
-	message_bytes, _ := json.Marshal(responses.BoardCastMessageResponse{
-		MessageaType: "read_all_message",
-		Data:         map[string]interface{}{"user_id": userID},
-	})
-	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- message_bytes
+	h.publish(c, chatUUID, "read_all_message", map[string]interface{}{"user_id": userID})
 
 	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
 		Message: "Chat messages retrieved successfully",
@@ -103,16 +101,14 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid chat ID format"))
 	}
 
-	chatMessage, err := h.chatUseCase.SendMessage(c.Context(), userID, chatUUID, req)
+	chatMessage, created, err := h.chatUseCase.SendMessage(c.Context(), userID, chatUUID, req)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	messageBytes, _ := json.Marshal(responses.BoardCastMessageResponse{
-		MessageaType: "send_message",
-		Data:         chatMessage,
-	})
-	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
+	if created {
+		h.publish(c, chatUUID, "send_message", chatMessage)
+	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
 		Message: "Message sent successfully",
@@ -120,6 +116,14 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 	})
 }
 
+// publish sends an event to the chat's sockets. The request has already succeeded, so a
+// failure is only logged.
+func (h *ChatHandler) publish(c *fiber.Ctx, chatID uuid.UUID, messageType string, data interface{}) {
+	if err := ws.Publish(c.Context(), h.pubsub, chatID, messageType, data); err != nil {
+		log.Printf("failed to publish %s event for chat %s: %v", messageType, chatID, err)
+	}
+}
+
 func (h *ChatHandler) handleError(c *fiber.Ctx, err error) error {
 	var status int
 	var errorResponse responses.ErrorResponse
@@ -132,6 +136,12 @@ func (h *ChatHandler) handleError(c *fiber.Ctx, err error) error {
 			Error: "Chat not found",
 			Code:  "CHAT_NOT_FOUND",
 		}
+	case errors.Is(err, chat.ErrMessageNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Message not found",
+			Code:  "MESSAGE_NOT_FOUND",
+		}
 	case errors.Is(err, chat.ErrUnauthorized):
 		status = fiber.StatusUnauthorized
 		errorResponse = responses.ErrorResponse{
@@ -177,11 +187,7 @@ func (h *ChatHandler) DeleteMessage(c *fiber.Ctx) error {
 		return h.handleError(c, err)
 	}
 
-	messageBytes, _ := json.Marshal(responses.BoardCastMessageResponse{
-		MessageaType: "delete_message",
-		Data:         map[string]interface{}{"message_id": messageID},
-	})
-	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
+	h.publish(c, chatUUID, "delete_message", map[string]interface{}{"message_id": messageID})
 
 	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
 		Message: "Message deleted successfully",
@@ -218,14 +224,10 @@ func (h *ChatHandler) UpdateMessage(c *fiber.Ctx) error {
 		return h.handleError(c, err)
 	}
 
-	messageBytes, _ := json.Marshal(responses.BoardCastMessageResponse{
-		MessageaType: "update_message",
-		Data: map[string]interface{}{
-			"message_id": messageID,
-			"message":    req.Message,
-		},
+	h.publish(c, chatUUID, "update_message", map[string]interface{}{
+		"message_id": messageID,
+		"message":    req.Message,
 	})
-	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
 
 	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
 		Message: "Message updated successfully",
@@ -329,4 +331,29 @@ func (h *ChatHandler) GetChatMessageOfSession(c *fiber.Ctx) error {
 		Message: "Chat messages retrieved successfully",
 		Data:    chat,
 	})
-}
\ No newline at end of file
+}
+func (h *ChatHandler) CreateWebSocketTicket(c *fiber.Ctx) error {
+	chatUUID, err := uuid.Parse(c.Params("chatID"))
+	if err != nil {
+		return h.handleError(c, errors.New("invalid chat ID format"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.chatUseCase.CheckChatMember(c.Context(), chatUUID, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	ticket, expiresAt, err := middleware.NewWebSocketTicket(userID, chatUUID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "WebSocket ticket created successfully",
+		Data: responses.WebSocketTicketResponse{
+			Ticket:    ticket,
+			ExpiresAt: expiresAt,
+		},
+	})
+}
@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/connection"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ConnectionHandler struct {
+	connectionUseCase connection.UseCase
+}
+
+func NewConnectionHandler(connectionUseCase connection.UseCase) *ConnectionHandler {
+	return &ConnectionHandler{
+		connectionUseCase: connectionUseCase,
+	}
+}
+
+func (h *ConnectionHandler) SetupConnectionRoutes(app *fiber.App) {
+	connections := app.Group("/api/connections")
+
+	// Protected routes
+	connections.Use(middleware.AuthRequired())
+	connections.Get("/", h.ListConnections)
+	connections.Post("/", h.SendRequest)
+	connections.Get("/requests", h.ListRequests)
+	connections.Get("/suggestions", h.GetPartnerSuggestions)
+	connections.Get("/mutual/:userId", h.GetMutualConnections)
+	connections.Post("/:id/accept", h.AcceptRequest)
+	connections.Post("/:id/decline", h.DeclineRequest)
+	connections.Delete("/:id", h.RemoveConnection)
+}
+
+func (h *ConnectionHandler) ListConnections(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.connectionUseCase.ListConnections(c.Context(), userID, c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ConnectionHandler) SendRequest(c *fiber.Ctx) error {
+	var req requests.SendConnectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.connectionUseCase.SendRequest(c.Context(), userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Connection request sent",
+		Data:    result,
+	})
+}
+
+func (h *ConnectionHandler) ListRequests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.connectionUseCase.ListRequests(c.Context(), userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ConnectionHandler) GetPartnerSuggestions(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.connectionUseCase.GetPartnerSuggestions(c.Context(), userID, c.QueryInt("min_sessions", 0), c.QueryInt("limit", 10))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ConnectionHandler) GetMutualConnections(c *fiber.Ctx) error {
+	otherID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid user ID",
+			Code:        "INVALID_ID",
+			Description: "The provided user ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.connectionUseCase.GetMutualConnections(c.Context(), userID, otherID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ConnectionHandler) AcceptRequest(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid connection ID",
+			Code:        "INVALID_ID",
+			Description: "The provided connection ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.connectionUseCase.AcceptRequest(c.Context(), id, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Connection request accepted",
+		Data:    result,
+	})
+}
+
+func (h *ConnectionHandler) DeclineRequest(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid connection ID",
+			Code:        "INVALID_ID",
+			Description: "The provided connection ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.connectionUseCase.DeclineRequest(c.Context(), id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Connection request declined",
+	})
+}
+
+func (h *ConnectionHandler) RemoveConnection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid connection ID",
+			Code:        "INVALID_ID",
+			Description: "The provided connection ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.connectionUseCase.RemoveConnection(c.Context(), id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Connection removed",
+	})
+}
+
+func (h *ConnectionHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, connection.ErrConnectionNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Connection not found",
+			Code:  "CONNECTION_NOT_FOUND",
+		}
+	case errors.Is(err, connection.ErrUserNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "User not found",
+			Code:  "USER_NOT_FOUND",
+		}
+	case errors.Is(err, connection.ErrAlreadyConnected):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Connection already exists",
+			Code:  "ALREADY_CONNECTED",
+		}
+	case errors.Is(err, connection.ErrUnauthorized):
+		status = fiber.StatusUnauthorized
+		errorResponse = responses.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  "UNAUTHORIZED",
+		}
+	case errors.Is(err, connection.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
@@ -0,0 +1,387 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/court"
+	"badbuddy/internal/usecase/user"
+	"badbuddy/internal/usecase/venue"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CourtHandler struct {
+	courtUseCase court.UseCase
+	venueUseCase venue.UseCase
+	userUseCase  user.UseCase
+}
+
+func NewCourtHandler(courtUseCase court.UseCase, venueUseCase venue.UseCase, userUseCase user.UseCase) *CourtHandler {
+	return &CourtHandler{
+		courtUseCase: courtUseCase,
+		venueUseCase: venueUseCase,
+		userUseCase:  userUseCase,
+	}
+}
+
+func (h *CourtHandler) SetupCourtRoutes(app *fiber.App) {
+	courts := app.Group("/api/courts")
+
+	// Protected routes, restricted to venue owners and admins
+	courts.Use(middleware.AuthRequired())
+	courts.Get("/", h.ListCourts)
+	courts.Post("/", h.CreateCourt)
+	courts.Get("/maintenance", h.ListVenueMaintenance)
+	courts.Get("/:id", h.GetCourt)
+	courts.Put("/:id", h.UpdateCourt)
+	courts.Put("/:id/status", h.UpdateCourtStatus)
+	courts.Delete("/:id", h.DeleteCourt)
+
+	// Maintenance windows
+	courts.Get("/:id/maintenance", h.ListMaintenance)
+	courts.Post("/:id/maintenance", h.ScheduleMaintenance)
+	courts.Delete("/:id/maintenance/:maintenanceId", h.CancelMaintenance)
+}
+
+func (h *CourtHandler) ListCourts(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	req := requests.ListCourtsRequest{
+		VenueID:  c.Query("venue_id"),
+		Status:   c.Query("status"),
+		Location: c.Query("location"),
+		PriceMin: c.QueryFloat("price_min", 0),
+		PriceMax: c.QueryFloat("price_max", 0),
+		Limit:    c.QueryInt("limit", 10),
+		Offset:   c.QueryInt("offset", 0),
+	}
+
+	// Admins manage courts across every venue, owners only see their own venues
+	if !isAdmin {
+		req.OwnerID = userID.String()
+	}
+
+	result, err := h.courtUseCase.ListCourts(c.Context(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *CourtHandler) CreateCourt(c *fiber.Ctx) error {
+	var req requests.CreateCourtRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	venueID, err := uuid.Parse(req.VenueID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid venue ID",
+			Code:        "INVALID_ID",
+			Description: "The provided venue ID is not in a valid format",
+		})
+	}
+
+	if err := h.authorizeVenue(c, venueID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.courtUseCase.CreateCourt(c.Context(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Court created successfully",
+		Data:    result,
+	})
+}
+
+func (h *CourtHandler) GetCourt(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.courtUseCase.GetCourt(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *CourtHandler) UpdateCourt(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	var req requests.UpdateCourtRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	result, err := h.courtUseCase.UpdateCourt(c.Context(), id, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Court updated successfully",
+		Data:    result,
+	})
+}
+
+func (h *CourtHandler) UpdateCourtStatus(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	var req requests.UpdateCourtStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	if err := h.courtUseCase.UpdateCourtStatus(c.Context(), id, req.Status); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Court status updated successfully",
+	})
+}
+
+func (h *CourtHandler) DeleteCourt(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	if err := h.courtUseCase.DeleteCourt(c.Context(), id); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Court deleted successfully",
+	})
+}
+
+func (h *CourtHandler) ScheduleMaintenance(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	var req requests.CreateMaintenanceWindowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.courtUseCase.ScheduleMaintenance(c.Context(), id, userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Maintenance scheduled successfully",
+		Data:    result,
+	})
+}
+
+func (h *CourtHandler) ListMaintenance(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.courtUseCase.ListMaintenance(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+// ListVenueMaintenance gives owners the upcoming maintenance across all courts of a venue
+func (h *CourtHandler) ListVenueMaintenance(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Query("venue_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid venue ID",
+			Code:        "INVALID_ID",
+			Description: "The venue_id query parameter must be a valid venue ID",
+		})
+	}
+
+	if err := h.authorizeVenue(c, venueID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.courtUseCase.ListVenueMaintenance(c.Context(), venueID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *CourtHandler) CancelMaintenance(c *fiber.Ctx) error {
+	id, err := h.authorizeCourt(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	maintenanceID, err := uuid.Parse(c.Params("maintenanceId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid maintenance ID",
+			Code:        "INVALID_ID",
+			Description: "The provided maintenance ID is not in a valid format",
+		})
+	}
+
+	if err := h.courtUseCase.CancelMaintenance(c.Context(), id, maintenanceID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Maintenance cancelled successfully",
+	})
+}
+
+// authorizeCourt parses the court ID from the path and checks that the caller
+// is an admin or owns the venue the court belongs to.
+func (h *CourtHandler) authorizeCourt(c *fiber.Ctx) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, court.ErrValidation
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if isAdmin {
+		return id, nil
+	}
+
+	isOwner, err := h.courtUseCase.IsOwner(c.Context(), id, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isOwner {
+		return uuid.Nil, court.ErrUnauthorized
+	}
+
+	return id, nil
+}
+
+func (h *CourtHandler) authorizeVenue(c *fiber.Ctx, venueID uuid.UUID) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+
+	isOwner, err := h.venueUseCase.IsOwner(c.Context(), venueID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return court.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func (h *CourtHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, court.ErrCourtNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Court not found",
+			Code:  "COURT_NOT_FOUND",
+		}
+	case errors.Is(err, court.ErrMaintenanceNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Maintenance window not found",
+			Code:  "MAINTENANCE_NOT_FOUND",
+		}
+	case errors.Is(err, court.ErrUnauthorized):
+		status = fiber.StatusUnauthorized
+		errorResponse = responses.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  "UNAUTHORIZED",
+		}
+	case errors.Is(err, court.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	case errors.Is(err, court.ErrCourtInUse):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Court in use",
+			Code:  "COURT_IN_USE",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
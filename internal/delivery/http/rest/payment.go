@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/infrastructure/payment"
+	"badbuddy/internal/usecase/booking"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentHandler struct {
+	bookingUseCase booking.UseCase
+	simulator      payment.Simulator
+}
+
+// NewPaymentHandler creates the gateway-facing payment routes. The simulator is
+// only set when the fake provider's simulator is explicitly enabled and may be nil.
+func NewPaymentHandler(bookingUseCase booking.UseCase, simulator payment.Simulator) *PaymentHandler {
+	return &PaymentHandler{
+		bookingUseCase: bookingUseCase,
+		simulator:      simulator,
+	}
+}
+
+func (h *PaymentHandler) SetupPaymentRoutes(app *fiber.App) {
+	payments := app.Group("/api/payments")
+
+	// Public routes, authenticated by the webhook signature
+	payments.Post("/webhook", h.HandleWebhook)
+
+	// Local development checkout for the fake provider. It settles any intent without a
+	// payment, so it is only registered when PAYMENT_SIMULATOR_ENABLED is set.
+	if h.simulator != nil {
+		payments.Post("/fake/:intentId", h.SimulatePayment)
+	}
+}
+
+func (h *PaymentHandler) HandleWebhook(c *fiber.Ctx) error {
+	if err := h.bookingUseCase.HandlePaymentWebhook(c.Context(), c.Body(), c.Get(payment.SignatureHeader)); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Webhook processed",
+	})
+}
+
+// SimulatePayment completes a fake checkout and delivers the resulting webhook in-process
+func (h *PaymentHandler) SimulatePayment(c *fiber.Ctx) error {
+	status := payment.Status(c.Query("status", string(payment.StatusSucceeded)))
+
+	payload, signature, err := h.simulator.Simulate(c.Params("intentId"), status)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	if err := h.bookingUseCase.HandlePaymentWebhook(c.Context(), payload, signature); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Payment " + string(status),
+	})
+}
+
+func (h *PaymentHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, booking.ErrInvalidWebhook):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Invalid webhook",
+			Code:  "INVALID_WEBHOOK",
+		}
+	case errors.Is(err, booking.ErrPaymentNotFound), errors.Is(err, payment.ErrIntentNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Payment not found",
+			Code:  "PAYMENT_NOT_FOUND",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
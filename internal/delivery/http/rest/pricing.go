@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/pricing"
+	"badbuddy/internal/usecase/user"
+	"badbuddy/internal/usecase/venue"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PricingHandler struct {
+	pricingUseCase pricing.UseCase
+	venueUseCase   venue.UseCase
+	userUseCase    user.UseCase
+}
+
+func NewPricingHandler(pricingUseCase pricing.UseCase, venueUseCase venue.UseCase, userUseCase user.UseCase) *PricingHandler {
+	return &PricingHandler{
+		pricingUseCase: pricingUseCase,
+		venueUseCase:   venueUseCase,
+		userUseCase:    userUseCase,
+	}
+}
+
+func (h *PricingHandler) SetupPricingRoutes(app *fiber.App) {
+	venues := app.Group("/api/venues")
+
+	// Protected routes, restricted to the venue's owner and admins
+	venues.Get("/:id/pricing-rules", middleware.AuthRequired(), h.ListRules)
+	venues.Post("/:id/pricing-rules", middleware.AuthRequired(), h.CreateRule)
+	venues.Delete("/:id/pricing-rules/:ruleId", middleware.AuthRequired(), h.DeleteRule)
+	venues.Get("/:id/holidays", middleware.AuthRequired(), h.ListHolidays)
+	venues.Post("/:id/holidays", middleware.AuthRequired(), h.CreateHoliday)
+	venues.Delete("/:id/holidays/:holidayId", middleware.AuthRequired(), h.DeleteHoliday)
+}
+
+func (h *PricingHandler) ListRules(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.pricingUseCase.ListRules(c.Context(), venueID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *PricingHandler) CreateRule(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	var req requests.CreatePricingRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	result, err := h.pricingUseCase.CreateRule(c.Context(), venueID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Pricing rule created successfully",
+		Data:    result,
+	})
+}
+
+func (h *PricingHandler) DeleteRule(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	ruleID, err := uuid.Parse(c.Params("ruleId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid pricing rule ID",
+			Code:        "INVALID_ID",
+			Description: "The provided pricing rule ID is not in a valid format",
+		})
+	}
+
+	if err := h.pricingUseCase.DeleteRule(c.Context(), venueID, ruleID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Pricing rule deleted successfully",
+	})
+}
+
+func (h *PricingHandler) ListHolidays(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.pricingUseCase.ListHolidays(c.Context(), venueID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *PricingHandler) CreateHoliday(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	var req requests.CreateHolidayRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	result, err := h.pricingUseCase.CreateHoliday(c.Context(), venueID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Holiday created successfully",
+		Data:    result,
+	})
+}
+
+func (h *PricingHandler) DeleteHoliday(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	holidayID, err := uuid.Parse(c.Params("holidayId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid holiday ID",
+			Code:        "INVALID_ID",
+			Description: "The provided holiday ID is not in a valid format",
+		})
+	}
+
+	if err := h.pricingUseCase.DeleteHoliday(c.Context(), venueID, holidayID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Holiday deleted successfully",
+	})
+}
+
+// authorizeVenue parses the venue ID from the path and checks that the caller
+// is an admin or owns the venue.
+func (h *PricingHandler) authorizeVenue(c *fiber.Ctx) (uuid.UUID, error) {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, pricing.ErrValidation
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if isAdmin {
+		return venueID, nil
+	}
+
+	isOwner, err := h.venueUseCase.IsOwner(c.Context(), venueID, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isOwner {
+		return uuid.Nil, pricing.ErrUnauthorized
+	}
+
+	return venueID, nil
+}
+
+func (h *PricingHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, pricing.ErrVenueNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Venue not found",
+			Code:  "VENUE_NOT_FOUND",
+		}
+	case errors.Is(err, pricing.ErrRuleNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Pricing rule not found",
+			Code:  "PRICING_RULE_NOT_FOUND",
+		}
+	case errors.Is(err, pricing.ErrHolidayNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Holiday not found",
+			Code:  "HOLIDAY_NOT_FOUND",
+		}
+	case errors.Is(err, pricing.ErrDuplicateHoliday):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Holiday already exists",
+			Code:  "DUPLICATE_HOLIDAY",
+		}
+	case errors.Is(err, pricing.ErrUnauthorized):
+		status = fiber.StatusUnauthorized
+		errorResponse = responses.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  "UNAUTHORIZED",
+		}
+	case errors.Is(err, pricing.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
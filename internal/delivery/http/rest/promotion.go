@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/promotion"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromotionHandler struct {
+	promotionUseCase promotion.UseCase
+}
+
+func NewPromotionHandler(promotionUseCase promotion.UseCase) *PromotionHandler {
+	return &PromotionHandler{
+		promotionUseCase: promotionUseCase,
+	}
+}
+
+func (h *PromotionHandler) SetupPromotionRoutes(app *fiber.App) {
+	promotions := app.Group("/api/promotions")
+
+	// Protected routes, restricted to venue owners and admins
+	promotions.Use(middleware.AuthRequired())
+	promotions.Get("/", h.ListPromotions)
+	promotions.Post("/", h.CreatePromotion)
+	promotions.Delete("/:id", h.DeactivatePromotion)
+}
+
+func (h *PromotionHandler) CreatePromotion(c *fiber.Ctx) error {
+	var req requests.CreatePromotionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.promotionUseCase.CreatePromotion(c.Context(), userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Promotion created successfully",
+		Data:    result,
+	})
+}
+
+func (h *PromotionHandler) ListPromotions(c *fiber.Ctx) error {
+	var venueID *uuid.UUID
+	if raw := c.Query("venue_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+				Error:       "Invalid venue ID",
+				Code:        "INVALID_ID",
+				Description: "The provided venue ID is not in a valid format",
+			})
+		}
+		venueID = &id
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.promotionUseCase.ListPromotions(c.Context(), userID, venueID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *PromotionHandler) DeactivatePromotion(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid promotion ID",
+			Code:        "INVALID_ID",
+			Description: "The provided promotion ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.promotionUseCase.DeactivatePromotion(c.Context(), id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Promotion deactivated successfully",
+	})
+}
+
+func (h *PromotionHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, promotion.ErrVenueNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Venue not found",
+			Code:  "VENUE_NOT_FOUND",
+		}
+	case errors.Is(err, promotion.ErrPromotionNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Promotion not found",
+			Code:  "PROMOTION_NOT_FOUND",
+		}
+	case errors.Is(err, promotion.ErrDuplicateCode):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Promotion code already exists",
+			Code:  "DUPLICATE_PROMOTION_CODE",
+		}
+	case errors.Is(err, promotion.ErrUnauthorized):
+		status = fiber.StatusUnauthorized
+		errorResponse = responses.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  "UNAUTHORIZED",
+		}
+	case errors.Is(err, promotion.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/review"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	reviewUseCase review.UseCase
+}
+
+func NewReviewHandler(reviewUseCase review.UseCase) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUseCase: reviewUseCase,
+	}
+}
+
+func (h *ReviewHandler) SetupReviewRoutes(app *fiber.App) {
+	reviews := app.Group("/api/reviews")
+
+	// Public routes
+	reviews.Get("/users/:id", h.GetUserReviews)
+
+	// Protected routes
+	reviews.Use(middleware.AuthRequired())
+	reviews.Post("/", h.CreateReview)
+	reviews.Get("/received", h.GetReceivedReviews)
+	reviews.Get("/given", h.GetGivenReviews)
+	reviews.Get("/sessions/:id/pending", h.GetReviewablePlayers)
+}
+
+func (h *ReviewHandler) CreateReview(c *fiber.Ctx) error {
+	var req requests.CreatePlayerReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.reviewUseCase.CreateReview(c.Context(), userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Review created successfully",
+		Data:    result,
+	})
+}
+
+func (h *ReviewHandler) GetUserReviews(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid user ID",
+			Code:        "INVALID_ID",
+			Description: "The provided user ID is not in a valid format",
+		})
+	}
+
+	result, err := h.reviewUseCase.GetReceivedReviews(c.Context(), userID, c.QueryInt("limit", 10), c.QueryInt("offset", 0))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ReviewHandler) GetReceivedReviews(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.reviewUseCase.GetReceivedReviews(c.Context(), userID, c.QueryInt("limit", 10), c.QueryInt("offset", 0))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ReviewHandler) GetGivenReviews(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.reviewUseCase.GetGivenReviews(c.Context(), userID, c.QueryInt("limit", 10), c.QueryInt("offset", 0))
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ReviewHandler) GetReviewablePlayers(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid session ID",
+			Code:        "INVALID_ID",
+			Description: "The provided session ID is not in a valid format",
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	players, err := h.reviewUseCase.GetReviewablePlayers(c.Context(), sessionID, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: players,
+	})
+}
+
+func (h *ReviewHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, review.ErrSessionNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Session not found",
+			Code:  "SESSION_NOT_FOUND",
+		}
+	case errors.Is(err, review.ErrSessionNotCompleted):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Session not completed",
+			Code:  "SESSION_NOT_COMPLETED",
+		}
+	case errors.Is(err, review.ErrSelfReview):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Cannot review yourself",
+			Code:  "SELF_REVIEW",
+		}
+	case errors.Is(err, review.ErrNotCoPlayer):
+		status = fiber.StatusForbidden
+		errorResponse = responses.ErrorResponse{
+			Error: "Not a co-player",
+			Code:  "NOT_CO_PLAYER",
+		}
+	case errors.Is(err, review.ErrAlreadyReviewed):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Already reviewed",
+			Code:  "ALREADY_REVIEWED",
+		}
+	case errors.Is(err, review.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
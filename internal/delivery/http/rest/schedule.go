@@ -0,0 +1,208 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/schedule"
+	"badbuddy/internal/usecase/user"
+	"badbuddy/internal/usecase/venue"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ScheduleHandler struct {
+	scheduleUseCase schedule.UseCase
+	venueUseCase    venue.UseCase
+	userUseCase     user.UseCase
+}
+
+func NewScheduleHandler(scheduleUseCase schedule.UseCase, venueUseCase venue.UseCase, userUseCase user.UseCase) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleUseCase: scheduleUseCase,
+		venueUseCase:    venueUseCase,
+		userUseCase:     userUseCase,
+	}
+}
+
+func (h *ScheduleHandler) SetupScheduleRoutes(app *fiber.App) {
+	venues := app.Group("/api/venues")
+
+	// Protected routes, restricted to the venue's owner and admins
+	venues.Get("/:id/schedule-overrides", middleware.AuthRequired(), h.ListOverrides)
+	venues.Post("/:id/schedule-overrides", middleware.AuthRequired(), h.CreateOverride)
+	venues.Get("/:id/schedule-overrides/:overrideId/affected-bookings", middleware.AuthRequired(), h.GetAffectedBookings)
+	venues.Delete("/:id/schedule-overrides/:overrideId", middleware.AuthRequired(), h.DeleteOverride)
+}
+
+func (h *ScheduleHandler) ListOverrides(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	result, err := h.scheduleUseCase.ListOverrides(c.Context(), venueID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ScheduleHandler) CreateOverride(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	var req requests.CreateScheduleOverrideRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		})
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.scheduleUseCase.CreateOverride(c.Context(), venueID, userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
+		Message: "Schedule override created successfully",
+		Data:    result,
+	})
+}
+
+func (h *ScheduleHandler) GetAffectedBookings(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	overrideID, err := uuid.Parse(c.Params("overrideId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid schedule override ID",
+			Code:        "INVALID_ID",
+			Description: "The provided schedule override ID is not in a valid format",
+		})
+	}
+
+	result, err := h.scheduleUseCase.GetAffectedBookings(c.Context(), venueID, overrideID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Data: result,
+	})
+}
+
+func (h *ScheduleHandler) DeleteOverride(c *fiber.Ctx) error {
+	venueID, err := h.authorizeVenue(c)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	overrideID, err := uuid.Parse(c.Params("overrideId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Error:       "Invalid schedule override ID",
+			Code:        "INVALID_ID",
+			Description: "The provided schedule override ID is not in a valid format",
+		})
+	}
+
+	if err := h.scheduleUseCase.DeleteOverride(c.Context(), venueID, overrideID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.SuccessResponse{
+		Message: "Schedule override deleted successfully",
+	})
+}
+
+// authorizeVenue parses the venue ID from the path and checks that the caller
+// is an admin or owns the venue.
+func (h *ScheduleHandler) authorizeVenue(c *fiber.Ctx) (uuid.UUID, error) {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, schedule.ErrValidation
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if isAdmin {
+		return venueID, nil
+	}
+
+	isOwner, err := h.venueUseCase.IsOwner(c.Context(), venueID, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !isOwner {
+		return uuid.Nil, schedule.ErrUnauthorized
+	}
+
+	return venueID, nil
+}
+
+func (h *ScheduleHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, schedule.ErrVenueNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Venue not found",
+			Code:  "VENUE_NOT_FOUND",
+		}
+	case errors.Is(err, schedule.ErrOverrideNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Schedule override not found",
+			Code:  "SCHEDULE_OVERRIDE_NOT_FOUND",
+		}
+	case errors.Is(err, schedule.ErrDuplicateOverride):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Opening hours already overridden",
+			Code:  "DUPLICATE_SCHEDULE_OVERRIDE",
+		}
+	case errors.Is(err, schedule.ErrUnauthorized):
+		status = fiber.StatusUnauthorized
+		errorResponse = responses.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  "UNAUTHORIZED",
+		}
+	case errors.Is(err, schedule.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(errorResponse)
+}
@@ -233,12 +233,19 @@ func (h *SessionHandler) JoinSession(c *fiber.Ctx) error {
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.JoinSession(c.Context(), sessionID, userID, req); err != nil {
+	result, err := h.sessionUseCase.JoinSession(c.Context(), sessionID, userID, req)
+	if err != nil {
 		return h.handleError(c, err)
 	}
 
+	message := "Successfully joined session"
+	if result.WaitlistPosition != nil {
+		message = "Session is full, you have been added to the waitlist"
+	}
+
 	return c.JSON(responses.SuccessResponse{
-		Message: "Successfully joined session",
+		Message: message,
+		Data:    result,
 	})
 }
 
@@ -275,7 +282,10 @@ func (h *SessionHandler) CancelSession(c *fiber.Ctx) error {
 
 	hostID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.CancelSession(c.Context(), sessionID, hostID); err != nil {
+	// scope=following also cancels the later occurrences of a recurring session
+	scope := c.Query("scope", "this")
+
+	if err := h.sessionUseCase.CancelSession(c.Context(), sessionID, hostID, scope); err != nil {
 		return h.handleError(c, err)
 	}
 
@@ -393,6 +403,12 @@ func (h *SessionHandler) handleError(c *fiber.Ctx, err error) error {
 			Error: "Unauthorized",
 			Code:  "UNAUTHORIZED",
 		}
+	case errors.Is(err, session.ErrCourtUnavailable):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Court not available",
+			Code:  "COURT_UNAVAILABLE",
+		}
 	case errors.Is(err, session.ErrValidation):
 		status = fiber.StatusBadRequest
 		errorResponse = responses.ErrorResponse{
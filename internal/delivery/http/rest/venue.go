@@ -6,6 +6,9 @@ import (
 	"badbuddy/internal/usecase/facility"
 	"badbuddy/internal/usecase/user"
 	"badbuddy/internal/usecase/venue"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -159,9 +162,16 @@ func (h *VenueHandler) ListVenues(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	offset := c.QueryInt("offset", 0)
 
-	venues, err := h.venueUseCase.ListVenues(c.Context(), location, limit, offset)
+	geo, err := parseGeoQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	venues, err := h.venueUseCase.ListVenues(c.Context(), location, geo, limit, offset)
+	if err != nil {
+		return c.Status(venueErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -185,9 +195,16 @@ func (h *VenueHandler) SearchVenues(c *fiber.Ctx) error {
 		facilityList = []string{}
 	}
 
-	venues, err := h.venueUseCase.SearchVenues(c.Context(), query, limit, offset, minPrice, maxPrice, location, facilityList)
+	geo, err := parseGeoQuery(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	venues, err := h.venueUseCase.SearchVenues(c.Context(), query, limit, offset, minPrice, maxPrice, location, facilityList, geo)
+	if err != nil {
+		return c.Status(venueErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -409,3 +426,42 @@ func (h *VenueHandler) validateFacilities(facility []requests.Facility, c *fiber
 	}
 	return true
 }
+
+// parseGeoQuery reads the optional lat, lng, radius_km and bbox query parameters
+func parseGeoQuery(c *fiber.Ctx) (requests.GeoQuery, error) {
+	geo := requests.GeoQuery{
+		RadiusKm: c.QueryFloat("radius_km", 0),
+		BBox:     c.Query("bbox"),
+	}
+
+	var err error
+	if geo.Latitude, err = queryCoordinate(c, "lat"); err != nil {
+		return geo, err
+	}
+	if geo.Longitude, err = queryCoordinate(c, "lng"); err != nil {
+		return geo, err
+	}
+
+	return geo, nil
+}
+
+// queryCoordinate reads an optional coordinate from the query string
+func queryCoordinate(c *fiber.Ctx, name string) (*float64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", name)
+	}
+	return &coordinate, nil
+}
+
+func venueErrorStatus(err error) int {
+	if errors.Is(err, venue.ErrValidation) {
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,52 @@
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// ackTTL is how long an envelope's ack is remembered, which covers a client resending
+// after a reconnect
+const ackTTL = 5 * time.Minute
+
+type ackEntry struct {
+	reply     Reply
+	expiresAt time.Time
+}
+
+// ackCache remembers the acks of envelopes that succeeded on this instance, keyed by user,
+// chat and envelope ID, so a resent envelope is answered again without being applied twice.
+// Sends are also deduplicated by the database, as a resend may reach another instance.
+type ackCache struct {
+	entries map[string]ackEntry
+	mu      sync.Mutex
+}
+
+func newAckCache() *ackCache {
+	return &ackCache{
+		entries: make(map[string]ackEntry),
+	}
+}
+
+func (c *ackCache) get(key string) (Reply, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return Reply{}, false
+	}
+	return entry.reply, true
+}
+
+func (c *ackCache) put(key string, reply Reply) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = ackEntry{reply: reply, expiresAt: now.Add(ackTTL)}
+}
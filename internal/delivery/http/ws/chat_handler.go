@@ -2,26 +2,106 @@
 package ws
 
 import (
+	"strings"
+
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/infrastructure/pubsub"
+	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/chat"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 )
 
-func ChatWebSocketHandler(hub *ChatHub) fiber.Handler {
-	return websocket.New(func(c *websocket.Conn) {
-		chatID := c.Params("chat_id")
-		room := hub.GetRoom(chatID)
-
-		room.Clients[c] = true
-		defer func() {
-			delete(room.Clients, c)
-			c.Close()
-		}()
-
-		for {
-			_, _, err := c.ReadMessage()
-			if err != nil {
-				break
-			}
+// bearerProtocol lets browsers, which cannot set headers on a WebSocket handshake, send
+// their token as the subprotocol list "bearer, <token>"
+const bearerProtocol = "bearer"
+
+// ChatWebSocketHandler authenticates the handshake and checks that the user belongs to
+// the chat before upgrading. The token is read from the Authorization header, the
+// subprotocol list or a ticket from POST /api/chats/:chatID/ws-ticket. Once connected,
+// clients can send, edit and delete messages over the socket as described in protocol.go.
+func ChatWebSocketHandler(hub *ChatHub, ps pubsub.PubSub, chatRepo interfaces.ChatRepository, chatUseCase chat.UseCase) fiber.Handler {
+	d := &dispatcher{
+		pubsub:      ps,
+		chatUseCase: chatUseCase,
+		acks:        newAckCache(),
+	}
+
+	upgrade := websocket.New(func(c *websocket.Conn) {
+		chatID := c.Locals("chatID").(uuid.UUID)
+		client := newClient(c, c.Locals("userID").(uuid.UUID))
+
+		hub.join(chatID.String(), client)
+		go client.writePump()
+
+		client.readPump(func(msg []byte) {
+			d.handle(client, chatID, msg)
+		})
+
+		// The connection is released once this handler returns, so wait for the writer
+		hub.leave(chatID.String(), client)
+		client.close(websocket.CloseNormalClosure, "")
+		<-client.stopped
+	}, websocket.Config{Subprotocols: []string{bearerProtocol}})
+
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		chatID, err := uuid.Parse(c.Params("chat_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid chat ID format",
+			})
+		}
+
+		userID, err := authenticate(c, chatID)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		isPartOfChat, err := chatRepo.IsUserPartOfChat(c.Context(), userID, chatID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to check chat membership",
+			})
 		}
-	})
+		if !isPartOfChat {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "user is not part of this chat",
+			})
+		}
+
+		c.Locals("userID", userID)
+		c.Locals("chatID", chatID)
+		return upgrade(c)
+	}
+}
+
+// authenticate finds the user of a handshake from a ticket, the Authorization header or
+// the subprotocol list, in that order
+func authenticate(c *fiber.Ctx, chatID uuid.UUID) (uuid.UUID, error) {
+	if ticket := c.Query("ticket"); ticket != "" {
+		return middleware.ParseWebSocketTicket(ticket, chatID)
+	}
+
+	if authHeader := c.Get(fiber.HeaderAuthorization); authHeader != "" {
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		if tokenString == authHeader {
+			return uuid.Nil, middleware.ErrInvalidFormat
+		}
+		return middleware.ParseToken(tokenString)
+	}
+
+	protocols := strings.Split(c.Get(fiber.HeaderSecWebSocketProtocol), ",")
+	if len(protocols) == 2 && strings.TrimSpace(protocols[0]) == bearerProtocol {
+		return middleware.ParseToken(strings.TrimSpace(protocols[1]))
+	}
+
+	return uuid.Nil, middleware.ErrNoAuthHeader
 }
@@ -2,47 +2,76 @@
 package ws
 
 import (
-	"github.com/gofiber/websocket/v2"
 	"sync"
-)
 
-type ChatRoom struct {
-	Clients   map[*websocket.Conn]bool
-	Broadcast chan []byte
-}
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+)
 
+// ChatHub tracks the clients connected to each chat. A chat has a room only while someone
+// is connected to it; the room is removed when its last client leaves.
 type ChatHub struct {
-	Rooms map[string]*ChatRoom
-	mu    sync.Mutex
+	rooms map[string]map[*Client]struct{}
+	mu    sync.RWMutex
 }
 
 func NewChatHub() *ChatHub {
 	return &ChatHub{
-		Rooms: make(map[string]*ChatRoom),
+		rooms: make(map[string]map[*Client]struct{}),
 	}
 }
 
-func (h *ChatHub) GetRoom(chatID string) *ChatRoom {
+func (h *ChatHub) join(chatID string, client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	if _, ok := h.Rooms[chatID]; !ok {
-		h.Rooms[chatID] = &ChatRoom{
-			Clients:   make(map[*websocket.Conn]bool),
-			Broadcast: make(chan []byte),
-		}
-		go h.runRoom(h.Rooms[chatID])
+	room, ok := h.rooms[chatID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		h.rooms[chatID] = room
+	}
+	room[client] = struct{}{}
+}
+
+func (h *ChatHub) leave(chatID string, client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	room, ok := h.rooms[chatID]
+	if !ok {
+		return
+	}
+	delete(room, client)
+	if len(room) == 0 {
+		delete(h.rooms, chatID)
+	}
+}
+
+// clients returns a snapshot of a chat's clients, so they can be written to without
+// holding the lock
+func (h *ChatHub) clients(chatID string) []*Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	clients := make([]*Client, 0, len(h.rooms[chatID]))
+	for client := range h.rooms[chatID] {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// Broadcast queues a message for every client connected to a chat on this instance. It
+// never blocks on a client; those that cannot keep up are disconnected. Events for other
+// instances go through Publish.
+func (h *ChatHub) Broadcast(chatID string, msg []byte) {
+	for _, client := range h.clients(chatID) {
+		client.enqueue(msg)
 	}
-	return h.Rooms[chatID]
 }
 
-func (h *ChatHub) runRoom(room *ChatRoom) {
-	for {
-		msg := <-room.Broadcast
-		for client := range room.Clients {
-			if err := client.WriteMessage(websocket.TextMessage, msg); err != nil {
-				client.Close()
-				delete(room.Clients, client)
-			}
+// DisconnectUser closes every socket a user has open on a chat on this instance, after
+// they have been removed from it
+func (h *ChatHub) DisconnectUser(chatID string, userID uuid.UUID) {
+	for _, client := range h.clients(chatID) {
+		if client.userID == userID {
+			client.close(websocket.ClosePolicyViolation, "removed from chat")
 		}
 	}
 }
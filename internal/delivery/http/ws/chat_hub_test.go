@@ -0,0 +1,166 @@
+package ws
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+)
+
+// newTestClient returns a client that is never written to a connection, so its queue can
+// be inspected directly
+func newTestClient() *Client {
+	return newClient(nil, uuid.New())
+}
+
+func isClosed(c *Client) bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestChatHubJoinAndLeave(t *testing.T) {
+	hub := NewChatHub()
+	a, b := newTestClient(), newTestClient()
+
+	hub.join("chat", a)
+	hub.join("chat", b)
+	if got := len(hub.clients("chat")); got != 2 {
+		t.Fatalf("expected 2 clients after joining, got %d", got)
+	}
+
+	hub.leave("chat", a)
+	clients := hub.clients("chat")
+	if len(clients) != 1 || clients[0] != b {
+		t.Fatalf("expected only the remaining client, got %v", clients)
+	}
+
+	// Leaving a chat that has no room is a no-op
+	hub.leave("other", a)
+}
+
+func TestChatHubRemovesEmptyRoom(t *testing.T) {
+	hub := NewChatHub()
+	a, b := newTestClient(), newTestClient()
+
+	hub.join("chat", a)
+	hub.join("chat", b)
+	hub.leave("chat", a)
+	if _, ok := hub.rooms["chat"]; !ok {
+		t.Fatal("expected the room to stay while a client is connected")
+	}
+
+	hub.leave("chat", b)
+	if _, ok := hub.rooms["chat"]; ok {
+		t.Fatal("expected the room to be removed once empty")
+	}
+}
+
+func TestChatHubBroadcastReachesEveryMember(t *testing.T) {
+	hub := NewChatHub()
+	members := []*Client{newTestClient(), newTestClient(), newTestClient()}
+	for _, c := range members {
+		hub.join("chat", c)
+	}
+	outsider := newTestClient()
+	hub.join("other", outsider)
+
+	hub.Broadcast("chat", []byte("hello"))
+
+	for i, c := range members {
+		select {
+		case msg := <-c.send:
+			if string(msg) != "hello" {
+				t.Fatalf("member %d got %q, want %q", i, msg, "hello")
+			}
+		default:
+			t.Fatalf("member %d did not receive the broadcast", i)
+		}
+	}
+	if len(outsider.send) != 0 {
+		t.Fatal("a client of another chat received the broadcast")
+	}
+}
+
+func TestChatHubBroadcastDropsSlowClient(t *testing.T) {
+	hub := NewChatHub()
+	slow, fast := newTestClient(), newTestClient()
+	hub.join("chat", slow)
+	hub.join("chat", fast)
+
+	for i := 0; i < sendBufferSize; i++ {
+		slow.send <- []byte("queued")
+	}
+
+	hub.Broadcast("chat", []byte("hello"))
+
+	if !isClosed(slow) {
+		t.Fatal("expected the client with a full buffer to be closed")
+	}
+	if slow.closeCode != websocket.CloseTryAgainLater {
+		t.Fatalf("expected close code %d, got %d", websocket.CloseTryAgainLater, slow.closeCode)
+	}
+	if isClosed(fast) {
+		t.Fatal("a client that keeps up was closed")
+	}
+	if len(fast.send) != 1 {
+		t.Fatalf("expected the other client to receive the broadcast, got %d messages", len(fast.send))
+	}
+
+	// Further broadcasts to the dropped client do not block or panic
+	hub.Broadcast("chat", []byte("again"))
+}
+
+func TestChatHubDisconnectUser(t *testing.T) {
+	hub := NewChatHub()
+	removed := newTestClient()
+	secondTab := newClient(nil, removed.userID)
+	other := newTestClient()
+	for _, c := range []*Client{removed, secondTab, other} {
+		hub.join("chat", c)
+	}
+
+	hub.DisconnectUser("chat", removed.userID)
+
+	for _, c := range []*Client{removed, secondTab} {
+		if !isClosed(c) {
+			t.Fatal("expected every socket of the removed user to be closed")
+		}
+		if c.closeCode != websocket.ClosePolicyViolation {
+			t.Fatalf("expected close code %d, got %d", websocket.ClosePolicyViolation, c.closeCode)
+		}
+	}
+	if isClosed(other) {
+		t.Fatal("a socket of another user was closed")
+	}
+}
+
+func TestChatHubConcurrentUse(t *testing.T) {
+	hub := NewChatHub()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			chatID := fmt.Sprintf("chat-%d", i%5)
+			c := newTestClient()
+			hub.join(chatID, c)
+			for j := 0; j < sendBufferSize*2; j++ {
+				hub.Broadcast(chatID, []byte("hello"))
+			}
+			hub.DisconnectUser(chatID, c.userID)
+			hub.leave(chatID, c)
+		}(i)
+	}
+	wg.Wait()
+
+	if len(hub.rooms) != 0 {
+		t.Fatalf("expected every room to be removed, %d left", len(hub.rooms))
+	}
+}
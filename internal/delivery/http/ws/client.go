@@ -0,0 +1,143 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// writeWait bounds how long a single write to a client may take
+	writeWait = 10 * time.Second
+
+	// pongWait is how long a client may stay silent before it is considered gone
+	pongWait = 60 * time.Second
+
+	// pingPeriod must be shorter than pongWait so a ping is answered in time
+	pingPeriod = pongWait * 9 / 10
+
+	// maxMessageSize limits what a client may send in one frame
+	maxMessageSize = 8 * 1024
+
+	// sendBufferSize is how many broadcasts may queue for a client before it is dropped
+	sendBufferSize = 64
+)
+
+// socket is the part of a WebSocket connection a client uses. *websocket.Conn satisfies it.
+type socket interface {
+	SetReadLimit(limit int64)
+	SetReadDeadline(t time.Time) error
+	SetPongHandler(h func(appData string) error)
+	ReadMessage() (messageType int, p []byte, err error)
+	SetWriteDeadline(t time.Time) error
+	WriteMessage(messageType int, data []byte) error
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	Close() error
+}
+
+// Client is one socket connected to a chat. Broadcasts are queued on send and written by
+// the client's own goroutine, so a slow client never holds up the rest of its room.
+type Client struct {
+	conn   socket
+	userID uuid.UUID
+	send   chan []byte
+
+	// done is closed when the client should disconnect, stopped once its writer has exited
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+func newClient(conn socket, userID uuid.UUID) *Client {
+	return &Client{
+		conn:    conn,
+		userID:  userID,
+		send:    make(chan []byte, sendBufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// enqueue queues a message without blocking. A client whose queue is full is not keeping
+// up and is disconnected rather than left to miss messages silently.
+func (c *Client) enqueue(msg []byte) {
+	select {
+	case c.send <- msg:
+	default:
+		c.close(websocket.CloseTryAgainLater, "client is too slow")
+	}
+}
+
+// close asks the writer to send a close frame and disconnect. Only the first call counts.
+func (c *Client) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+	})
+}
+
+// reply queues an answer to one of the client's envelopes
+func (c *Client) reply(reply Reply) {
+	msg, err := json.Marshal(reply)
+	if err != nil {
+		return
+	}
+	c.enqueue(msg)
+}
+
+// readPump passes each message the client sends to handle until the client disconnects,
+// keeping the read deadline alive on pongs
+func (c *Client) readPump(handle func(msg []byte)) {
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		handle(msg)
+	}
+}
+
+// writePump is the only goroutine that writes to the connection. It writes queued
+// messages and pings until the client is closed, then sends the close frame.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+		close(c.stopped)
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-c.done:
+			if c.closeCode != websocket.CloseAbnormalClosure {
+				closeMessage := websocket.FormatCloseMessage(c.closeCode, c.closeText)
+				_ = c.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeWait))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package ws
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+)
+
+// fakeSocket records what a client writes. Writes block while blocked is open, like a
+// peer that has stopped reading.
+type fakeSocket struct {
+	mu       sync.Mutex
+	messages []string
+	controls []int
+	closed   bool
+	blocked  chan struct{}
+}
+
+func (s *fakeSocket) SetReadLimit(int64)                {}
+func (s *fakeSocket) SetReadDeadline(time.Time) error   { return nil }
+func (s *fakeSocket) SetPongHandler(func(string) error) {}
+func (s *fakeSocket) SetWriteDeadline(time.Time) error  { return nil }
+func (s *fakeSocket) ReadMessage() (int, []byte, error) { return 0, nil, errors.New("not readable") }
+
+func (s *fakeSocket) WriteMessage(_ int, data []byte) error {
+	if s.blocked != nil {
+		<-s.blocked
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, string(data))
+	return nil
+}
+
+func (s *fakeSocket) WriteControl(messageType int, _ []byte, _ time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.controls = append(s.controls, messageType)
+	return nil
+}
+
+func (s *fakeSocket) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func waitStopped(t *testing.T, c *Client) {
+	t.Helper()
+	select {
+	case <-c.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("writer did not stop")
+	}
+}
+
+func TestClientWritesQueuedMessagesThenCloses(t *testing.T) {
+	conn := &fakeSocket{}
+	c := newClient(conn, uuid.New())
+	go c.writePump()
+
+	c.enqueue([]byte("one"))
+	c.enqueue([]byte("two"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn.mu.Lock()
+		written := len(conn.messages)
+		conn.mu.Unlock()
+		if written == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 messages to be written, got %d", written)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	c.close(websocket.CloseNormalClosure, "")
+	c.close(websocket.ClosePolicyViolation, "ignored")
+	waitStopped(t, c)
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if conn.messages[0] != "one" || conn.messages[1] != "two" {
+		t.Fatalf("messages written out of order: %v", conn.messages)
+	}
+	if len(conn.controls) != 1 || conn.controls[0] != websocket.CloseMessage {
+		t.Fatalf("expected a single close frame, got %v", conn.controls)
+	}
+	if !conn.closed {
+		t.Fatal("expected the connection to be closed")
+	}
+	if c.closeCode != websocket.CloseNormalClosure {
+		t.Fatalf("expected the first close code to win, got %d", c.closeCode)
+	}
+}
+
+func TestClientDropsSlowConsumer(t *testing.T) {
+	conn := &fakeSocket{blocked: make(chan struct{})}
+	c := newClient(conn, uuid.New())
+	hub := NewChatHub()
+	hub.join("chat", c)
+	go c.writePump()
+
+	// The writer is stuck on the first message, so the rest fill the buffer. Broadcast must
+	// not block on the stuck client once the buffer is full.
+	broadcasted := make(chan struct{})
+	go func() {
+		for i := 0; i <= sendBufferSize+1; i++ {
+			hub.Broadcast("chat", []byte("hello"))
+		}
+		close(broadcasted)
+	}()
+
+	select {
+	case <-broadcasted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("broadcast blocked on a slow client")
+	}
+	if !isClosed(c) {
+		t.Fatal("expected the slow client to be closed")
+	}
+
+	// Once the peer catches up the writer notices the close and shuts the connection
+	close(conn.blocked)
+	waitStopped(t, c)
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if !conn.closed {
+		t.Fatal("expected the connection to be closed")
+	}
+	if len(conn.controls) != 1 || conn.controls[0] != websocket.CloseMessage {
+		t.Fatalf("expected a close frame, got %v", conn.controls)
+	}
+	if c.closeCode != websocket.CloseTryAgainLater {
+		t.Fatalf("expected close code %d, got %d", websocket.CloseTryAgainLater, c.closeCode)
+	}
+}
@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/infrastructure/pubsub"
+
+	"github.com/google/uuid"
+)
+
+// Chat events are published on a channel per chat, so every instance can deliver them to
+// the sockets it holds
+const (
+	chatChannelPrefix       = "chat:"
+	disconnectChannelPrefix = "chat_disconnect:"
+)
+
+// Publish sends an event to everyone connected to a chat, on whichever instance they are
+func Publish(ctx context.Context, ps pubsub.PubSub, chatID uuid.UUID, messageType string, data interface{}) error {
+	messageBytes, err := json.Marshal(responses.BoardCastMessageResponse{
+		MessageaType: messageType,
+		Data:         data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode chat event: %w", err)
+	}
+
+	return ps.Publish(ctx, chatChannelPrefix+chatID.String(), messageBytes)
+}
+
+// PublishDisconnect closes a user's sockets on a chat on every instance
+func PublishDisconnect(ctx context.Context, ps pubsub.PubSub, chatID, userID uuid.UUID) error {
+	payload, err := json.Marshal(userID)
+	if err != nil {
+		return err
+	}
+
+	return ps.Publish(ctx, disconnectChannelPrefix+chatID.String(), payload)
+}
+
+// Listen delivers the chat events published on any instance to the hub's clients
+func (h *ChatHub) Listen(ctx context.Context, ps pubsub.PubSub) error {
+	return ps.Subscribe(ctx, func(channel string, payload []byte) {
+		if chatID, ok := strings.CutPrefix(channel, chatChannelPrefix); ok {
+			h.Broadcast(chatID, payload)
+			return
+		}
+
+		if chatID, ok := strings.CutPrefix(channel, disconnectChannelPrefix); ok {
+			var userID uuid.UUID
+			if err := json.Unmarshal(payload, &userID); err == nil {
+				h.DisconnectUser(chatID, userID)
+			}
+		}
+	})
+}
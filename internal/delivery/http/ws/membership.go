@@ -0,0 +1,39 @@
+package ws
+
+import (
+	"context"
+	"log"
+
+	"badbuddy/internal/infrastructure/pubsub"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+// membershipChatRepository disconnects a user's sockets when they are removed from a chat,
+// so they stop receiving its broadcasts without having to reconnect
+type membershipChatRepository struct {
+	interfaces.ChatRepository
+	pubsub pubsub.PubSub
+}
+
+// NewMembershipChatRepository wraps a chat repository so removals from a chat also close
+// the removed user's sockets, on every instance
+func NewMembershipChatRepository(chatRepo interfaces.ChatRepository, ps pubsub.PubSub) interfaces.ChatRepository {
+	return &membershipChatRepository{
+		ChatRepository: chatRepo,
+		pubsub:         ps,
+	}
+}
+
+func (r *membershipChatRepository) RemoveUserFromChat(ctx context.Context, userID, chatID uuid.UUID) error {
+	if err := r.ChatRepository.RemoveUserFromChat(ctx, userID, chatID); err != nil {
+		return err
+	}
+
+	// The user is already out of the chat, so failing to reach their sockets is not an error
+	if err := PublishDisconnect(ctx, r.pubsub, chatID, userID); err != nil {
+		log.Printf("failed to disconnect user %s from chat %s: %v", userID, chatID, err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/infrastructure/pubsub"
+	"badbuddy/internal/usecase/chat"
+
+	"github.com/google/uuid"
+)
+
+// Envelope types a client may send over the socket
+const (
+	EnvelopeSend   = "send"
+	EnvelopeEdit   = "edit"
+	EnvelopeDelete = "delete"
+	EnvelopeTyping = "typing"
+	EnvelopeRead   = "read"
+)
+
+// Envelope types only the server sends, in reply to a client's envelope
+const (
+	EnvelopeAck   = "ack"
+	EnvelopeError = "error"
+)
+
+// requestTimeout bounds the work done for a single envelope
+const requestTimeout = 10 * time.Second
+
+// Envelope is a message sent by a client. ID is generated by the client and echoed in the
+// ack or error; resending an envelope with the same ID does not repeat its effect.
+type Envelope struct {
+	Type string          `json:"type"`
+	ID   string          `json:"id"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// EditData is the data of an edit envelope. Send envelopes carry only the message.
+type EditData struct {
+	MessageID string `json:"message_id"`
+	Message   string `json:"message"`
+}
+
+type DeleteData struct {
+	MessageID string `json:"message_id"`
+}
+
+type TypingData struct {
+	Typing bool `json:"typing"`
+}
+
+// Reply answers a client's envelope, with the result of an ack or the reason of an error
+type Reply struct {
+	Type  string                   `json:"type"`
+	ID    string                   `json:"id,omitempty"`
+	Data  interface{}              `json:"data,omitempty"`
+	Error *responses.ErrorResponse `json:"error,omitempty"`
+}
+
+var errInvalidEnvelope = errors.New("invalid envelope")
+
+// dispatcher handles the envelopes clients send on one chat. Changes to messages go
+// through the chat use case and are broadcast with the same events as the REST API.
+type dispatcher struct {
+	pubsub      pubsub.PubSub
+	chatUseCase chat.UseCase
+	acks        *ackCache
+}
+
+func (d *dispatcher) handle(client *Client, chatID uuid.UUID, raw []byte) {
+	var envelope Envelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		client.reply(Reply{Type: EnvelopeError, Error: errorResponse(errInvalidEnvelope)})
+		return
+	}
+
+	if envelope.Type != EnvelopeTyping && envelope.ID == "" {
+		client.reply(Reply{Type: EnvelopeError, Error: errorResponse(fmt.Errorf("%w: id is required", errInvalidEnvelope))})
+		return
+	}
+
+	// Envelope IDs are only unique within a chat, so the same ID sent to another chat is a new envelope
+	ackKey := client.userID.String() + ":" + chatID.String() + ":" + envelope.ID
+	if reply, ok := d.acks.get(ackKey); ok {
+		client.reply(reply)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	data, err := d.apply(ctx, client.userID, chatID, envelope)
+	if err != nil {
+		client.reply(Reply{Type: EnvelopeError, ID: envelope.ID, Error: errorResponse(err)})
+		return
+	}
+
+	// Typing indicators without an ID are not acknowledged
+	if envelope.ID == "" {
+		return
+	}
+
+	reply := Reply{Type: EnvelopeAck, ID: envelope.ID, Data: data}
+	d.acks.put(ackKey, reply)
+	client.reply(reply)
+}
+
+// apply carries out an envelope and broadcasts its event, returning the data of the ack
+func (d *dispatcher) apply(ctx context.Context, userID, chatID uuid.UUID, envelope Envelope) (interface{}, error) {
+	switch envelope.Type {
+	case EnvelopeSend:
+		var req requests.SendAndUpdateMessageRequest
+		if err := json.Unmarshal(envelope.Data, &req); err != nil {
+			return nil, errInvalidEnvelope
+		}
+
+		// The envelope ID is stored with the message, so a resend is not saved twice even
+		// when it reaches another instance
+		req.ClientMessageID = &envelope.ID
+		chatMessage, created, err := d.chatUseCase.SendMessage(ctx, userID, chatID, req)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			d.broadcast(ctx, chatID, "send_message", chatMessage)
+		}
+		return chatMessage, nil
+
+	case EnvelopeEdit:
+		var data EditData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return nil, errInvalidEnvelope
+		}
+		messageID, err := uuid.Parse(data.MessageID)
+		if err != nil {
+			return nil, errInvalidEnvelope
+		}
+
+		req := requests.SendAndUpdateMessageRequest{Message: data.Message}
+		if err := d.chatUseCase.UpdateMessage(ctx, chatID, messageID, userID, req); err != nil {
+			return nil, err
+		}
+		event := map[string]interface{}{
+			"message_id": data.MessageID,
+			"message":    data.Message,
+		}
+		d.broadcast(ctx, chatID, "update_message", event)
+		return event, nil
+
+	case EnvelopeDelete:
+		var data DeleteData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return nil, errInvalidEnvelope
+		}
+		messageID, err := uuid.Parse(data.MessageID)
+		if err != nil {
+			return nil, errInvalidEnvelope
+		}
+
+		if err := d.chatUseCase.DeleteMessage(ctx, chatID, messageID, userID); err != nil {
+			return nil, err
+		}
+		event := map[string]interface{}{"message_id": data.MessageID}
+		d.broadcast(ctx, chatID, "delete_message", event)
+		return event, nil
+
+	case EnvelopeTyping:
+		var data TypingData
+		if len(envelope.Data) > 0 {
+			if err := json.Unmarshal(envelope.Data, &data); err != nil {
+				return nil, errInvalidEnvelope
+			}
+		}
+
+		event := map[string]interface{}{
+			"user_id": userID,
+			"typing":  data.Typing,
+		}
+		d.broadcast(ctx, chatID, "typing", event)
+		return event, nil
+
+	case EnvelopeRead:
+		if err := d.chatUseCase.MarkChatAsRead(ctx, chatID, userID); err != nil {
+			return nil, err
+		}
+		event := map[string]interface{}{"user_id": userID}
+		d.broadcast(ctx, chatID, "read_all_message", event)
+		return event, nil
+
+	default:
+		return nil, errInvalidEnvelope
+	}
+}
+
+func (d *dispatcher) broadcast(ctx context.Context, chatID uuid.UUID, messageType string, data interface{}) {
+	if err := Publish(ctx, d.pubsub, chatID, messageType, data); err != nil {
+		log.Printf("failed to publish %s event for chat %s: %v", messageType, chatID, err)
+	}
+}
+
+// errorResponse describes an error with the same codes as the chat REST API
+func errorResponse(err error) *responses.ErrorResponse {
+	var resp responses.ErrorResponse
+	switch {
+	case errors.Is(err, chat.ErrChatNotFound):
+		resp = responses.ErrorResponse{Error: "Chat not found", Code: "CHAT_NOT_FOUND"}
+	case errors.Is(err, chat.ErrMessageNotFound):
+		resp = responses.ErrorResponse{Error: "Message not found", Code: "MESSAGE_NOT_FOUND"}
+	case errors.Is(err, chat.ErrUnauthorized):
+		resp = responses.ErrorResponse{Error: "Unauthorized", Code: "UNAUTHORIZED"}
+	case errors.Is(err, chat.ErrValidation), errors.Is(err, errInvalidEnvelope):
+		resp = responses.ErrorResponse{Error: "Validation error", Code: "VALIDATION_ERROR"}
+	default:
+		resp = responses.ErrorResponse{Error: "Internal server error", Code: "INTERNAL_ERROR"}
+	}
+
+	resp.Description = err.Error()
+	return &resp
+}
@@ -11,6 +11,7 @@ import (
 type BookingStatus string
 type PaymentStatus string
 type PaymentMethod string
+type RefundStatus string
 
 const (
 	BookingStatusPending   BookingStatus = "pending"
@@ -27,22 +28,37 @@ const (
 	PaymentMethodTransfer PaymentMethod = "transfer"
 	PaymentMethodCard     PaymentMethod = "card"
 	PaymentMethodQR       PaymentMethod = "qr"
+
+	// A pending refund is recorded but not yet sent to the payment gateway
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusCompleted RefundStatus = "completed"
 )
 
+// BookingTimeZone is the zone booking dates and times are expressed in
+var BookingTimeZone = time.FixedZone("ICT", 7*3600)
+
 // CourtBooking represents a court booking
 type CourtBooking struct {
-	ID          uuid.UUID     `db:"id"`
-	CourtID     uuid.UUID     `db:"court_id"`
-	UserID      uuid.UUID     `db:"user_id"`
-	Date        time.Time     `db:"booking_date"`
-	StartTime   time.Time     `db:"start_time"`
-	EndTime     time.Time     `db:"end_time"`
-	TotalAmount float64       `db:"total_amount"`
-	Status      BookingStatus `db:"status"`
-	Notes       *string       `db:"notes"`
-	CreatedAt   time.Time     `db:"created_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
-	CancelledAt *time.Time    `db:"cancelled_at"`
+	ID            uuid.UUID     `db:"id"`
+	CourtID       uuid.UUID     `db:"court_id"`
+	UserID        uuid.UUID     `db:"user_id"`
+	SessionID     *uuid.UUID    `db:"session_id"`
+	SeriesID      *uuid.UUID    `db:"series_id"`
+	OrderID       *uuid.UUID    `db:"order_id"`
+	Date          time.Time     `db:"booking_date"`
+	StartTime     time.Time     `db:"start_time"`
+	EndTime       time.Time     `db:"end_time"`
+	TotalAmount   float64       `db:"total_amount"`
+	Status        BookingStatus `db:"status"`
+	Notes         *string       `db:"notes"`
+	CreatedAt     time.Time     `db:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"`
+	CancelledAt   *time.Time    `db:"cancelled_at"`
+	HoldExpiresAt *time.Time    `db:"hold_expires_at"`
+
+	// Promotion applied to the booking; TotalAmount is already reduced by DiscountAmount
+	PromotionID    *uuid.UUID `db:"promotion_id"`
+	DiscountAmount float64    `db:"discount_amount"`
 
 	// Joined fields
 	CourtName     string  `db:"court_name"`
@@ -59,6 +75,7 @@ type CourtBooking struct {
 type Payment struct {
 	ID            uuid.UUID     `db:"id"`
 	BookingID     uuid.UUID     `db:"booking_id"`
+	OrderID       *uuid.UUID    `db:"order_id"`
 	UserID        uuid.UUID     `db:"user_id"`
 	Amount        float64       `db:"amount"`
 	Status        PaymentStatus `db:"status"`
@@ -68,6 +85,70 @@ type Payment struct {
 	UpdatedAt     time.Time     `db:"updated_at"`
 }
 
+// Reasons recorded alongside booking status transitions
+const (
+	BookingReasonCreated          = "created"
+	BookingReasonCancelledByUser  = "cancelled_by_user"
+	BookingReasonPaymentUpdated   = "payment_updated"
+	BookingReasonHoldExpired      = "hold_expired"
+	BookingReasonSessionCancelled = "session_cancelled"
+	BookingReasonPaidAfterExpiry  = "paid_after_expiry"
+)
+
+// BookingStatusHistory records a single status transition of a booking
+type BookingStatusHistory struct {
+	ID         uuid.UUID      `db:"id"`
+	BookingID  uuid.UUID      `db:"booking_id"`
+	FromStatus *BookingStatus `db:"from_status"`
+	ToStatus   BookingStatus  `db:"to_status"`
+	Reason     string         `db:"reason"`
+	ChangedBy  *uuid.UUID     `db:"changed_by"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+// Refund records money returned to the payer of a cancelled booking
+type Refund struct {
+	ID            uuid.UUID `db:"id"`
+	BookingID     uuid.UUID `db:"booking_id"`
+	PaymentID     uuid.UUID `db:"payment_id"`
+	Amount        float64   `db:"amount"`
+	RefundPercent int       `db:"refund_percent"`
+	Reason        string    `db:"reason"`
+	// ProviderRefundID is the gateway's reference for refunds of card and QR payments
+	ProviderRefundID *string      `db:"provider_refund_id"`
+	Status           RefundStatus `db:"status"`
+	CreatedAt        time.Time    `db:"created_at"`
+
+	// Joined fields
+	TransactionID *string `db:"transaction_id"`
+}
+
+// NewBookingStatusHistory records a booking moving between statuses.
+// An empty from status marks the booking's creation.
+func NewBookingStatusHistory(bookingID uuid.UUID, from, to BookingStatus, reason string, changedBy *uuid.UUID) *BookingStatusHistory {
+	history := &BookingStatusHistory{
+		ID:        uuid.New(),
+		BookingID: bookingID,
+		ToStatus:  to,
+		Reason:    reason,
+		ChangedBy: changedBy,
+		CreatedAt: time.Now(),
+	}
+	if from != "" {
+		history.FromStatus = &from
+	}
+	return history
+}
+
+// HoldExpiry returns when a booking created at the given time stops holding its slot unpaid
+func HoldExpiry(createdAt time.Time, holdMinutes int) *time.Time {
+	if holdMinutes <= 0 {
+		holdMinutes = DefaultBookingHoldMinutes
+	}
+	expiresAt := createdAt.Add(time.Duration(holdMinutes) * time.Minute)
+	return &expiresAt
+}
+
 // BookingDetail represents a detailed court booking with all related information
 type BookingDetail struct {
 	CourtBooking
@@ -148,18 +229,33 @@ func (b *CourtBooking) CalculateTotalAmount() float64 {
 	return duration * b.PricePerHour
 }
 
-// CanBeCancelled checks if the booking can be cancelled based on its status and time
-func (b *CourtBooking) CanBeCancelled() bool {
-	if b.Status == BookingStatusCancelled || b.Status == BookingStatusConfirmed {
+// CanBeCancelled checks if the booking can be cancelled based on its status and time.
+// Paid bookings can be cancelled too; the venue's cancellation policy decides the refund.
+func (b *CourtBooking) CanBeCancelled(now time.Time) bool {
+	if b.Status == BookingStatusCancelled {
 		return false
 	}
-	return true
-	// Check if booking start time is more than 24 hours away
-	// now := time.Now()
-	// bookingStart := time.Date(
-	// 	b.Date.Year(), b.Date.Month(), b.Date.Day(),
-	// 	b.StartTime.Hour(), b.StartTime.Minute(), 0, 0, time.Local)
-	// return now.Add(24 * time.Hour).Before(bookingStart)
+	return now.Before(b.StartsAt())
+}
+
+// StartsAt returns the moment the booked slot begins
+func (b *CourtBooking) StartsAt() time.Time {
+	return time.Date(
+		b.Date.Year(), b.Date.Month(), b.Date.Day(),
+		b.StartTime.Hour(), b.StartTime.Minute(), 0, 0,
+		BookingTimeZone)
+}
+
+// EndsAt returns the moment the booked slot ends. A slot ending at 00:00 ends at midnight.
+func (b *CourtBooking) EndsAt() time.Time {
+	end := time.Date(
+		b.Date.Year(), b.Date.Month(), b.Date.Day(),
+		b.EndTime.Hour(), b.EndTime.Minute(), 0, 0,
+		BookingTimeZone)
+	if !end.After(b.StartsAt()) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
 }
 
 // IsOverlapping checks if this booking overlaps with another booking
@@ -192,27 +288,62 @@ func (b *CourtBooking) ToResponse() *responses.BookingResponse {
 		resp.Notes = *b.Notes
 	}
 
+	if b.SessionID != nil {
+		resp.SessionID = b.SessionID.String()
+	}
+
+	if b.SeriesID != nil {
+		resp.SeriesID = b.SeriesID.String()
+	}
+
+	if b.OrderID != nil {
+		resp.OrderID = b.OrderID.String()
+	}
+
+	if b.PromotionID != nil {
+		resp.PromotionID = b.PromotionID.String()
+		resp.Discount = b.DiscountAmount
+	}
+
 	if b.CancelledAt != nil {
 		resp.CancelledAt = b.CancelledAt.Format(time.RFC3339)
 	}
 
+	if b.HoldExpiresAt != nil && b.Status == BookingStatusPending {
+		resp.HoldExpiresAt = b.HoldExpiresAt.Format(time.RFC3339)
+	}
+
 	if b.Payment != nil {
-		resp.Payment = &responses.PaymentResponse{
-			ID:            b.Payment.ID.String(),
-			Amount:        b.Payment.Amount,
-			Status:        string(b.Payment.Status),
-			PaymentMethod: string(b.Payment.PaymentMethod),
-			CreatedAt:     b.Payment.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:     b.Payment.UpdatedAt.Format(time.RFC3339),
-		}
-		if b.Payment.TransactionID != nil {
-			resp.Payment.TransactionID = *b.Payment.TransactionID
-		}
+		resp.Payment = b.Payment.ToResponse()
 	}
 
 	return resp
 }
 
+// ToResponse converts a Payment to a PaymentResponse
+func (p *Payment) ToResponse() *responses.PaymentResponse {
+	resp := &responses.PaymentResponse{
+		ID:            p.ID.String(),
+		Amount:        p.Amount,
+		Status:        string(p.Status),
+		PaymentMethod: string(p.PaymentMethod),
+		CreatedAt:     p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     p.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if p.TransactionID != nil {
+		resp.TransactionID = *p.TransactionID
+	}
+
+	return resp
+}
+
+// UsesGateway reports whether the method is settled through the payment gateway
+// rather than confirmed by hand at the venue
+func (m PaymentMethod) UsesGateway() bool {
+	return m == PaymentMethodCard || m == PaymentMethodQR
+}
+
 // Validate validates the payment data
 func (p *Payment) Validate() error {
 	if p.BookingID == uuid.Nil {
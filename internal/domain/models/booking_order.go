@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBookingOrderItems bounds how many slots can be checked out in a single order
+const MaxBookingOrderItems = 10
+
+// BookingOrder groups court bookings that are checked out and paid for together.
+// Either every booking of an order is created or none of them.
+type BookingOrder struct {
+	ID          uuid.UUID `db:"id"`
+	UserID      uuid.UUID `db:"user_id"`
+	TotalAmount float64   `db:"total_amount"`
+	CreatedAt   time.Time `db:"created_at"`
+}
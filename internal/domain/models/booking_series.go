@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBookingSeriesOccurrences bounds how many bookings a single contract booking may create
+const MaxBookingSeriesOccurrences = 104
+
+// BookingSeries is a contract booking of the same court and time every week. Its
+// occurrences are stored as individual court bookings linked through series_id.
+type BookingSeries struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	CourtID   uuid.UUID  `db:"court_id"`
+	Weekdays  WeekdaySet `db:"weekdays"`
+	StartsOn  time.Time  `db:"starts_on"`
+	EndsOn    time.Time  `db:"ends_on"`
+	StartTime time.Time  `db:"start_time"`
+	EndTime   time.Time  `db:"end_time"`
+	Notes     *string    `db:"notes"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// Validate checks the date range and that it stays within MaxBookingSeriesOccurrences
+func (s *BookingSeries) Validate() error {
+	if s.Weekdays <= 0 {
+		return fmt.Errorf("at least one weekday is required")
+	}
+	if s.EndsOn.Before(s.StartsOn) {
+		return fmt.Errorf("end date must not be before the start date")
+	}
+	if !s.StartTime.Before(s.EndTime) {
+		return fmt.Errorf("start time must be before end time")
+	}
+
+	// Look one past the cap so a range that is too long is rejected, not cut short
+	dates := WeeklyDates(s.StartsOn, &s.EndsOn, s.Weekdays, 1, MaxBookingSeriesOccurrences+1)
+	if len(dates) == 0 {
+		return fmt.Errorf("date range does not include any of the weekdays")
+	}
+	if len(dates) > MaxBookingSeriesOccurrences {
+		return fmt.Errorf("recurring booking cannot create more than %d bookings", MaxBookingSeriesOccurrences)
+	}
+	return nil
+}
+
+// Dates lists the dates the series books the court on
+func (s *BookingSeries) Dates() []time.Time {
+	return WeeklyDates(s.StartsOn, &s.EndsOn, s.Weekdays, 1, MaxBookingSeriesOccurrences)
+}
@@ -26,11 +26,11 @@ const (
 
 // Chat represents a conversation between users
 type Chat struct {
-	ID   uuid.UUID `db:"id"`
-	Type ChatType  `db:"type"`
-	SessionID *uuid.UUID `db:"session_id"`
-	LastMessage *Message `db:"last_message,omitempty"`
-	Users []User `db:"users,omitempty"`
+	ID          uuid.UUID  `db:"id"`
+	Type        ChatType   `db:"type"`
+	SessionID   *uuid.UUID `db:"session_id"`
+	LastMessage *Message   `db:"last_message,omitempty"`
+	Users       []User     `db:"users,omitempty"`
 }
 
 // ChatParticipant represents a user in a chat
@@ -49,26 +49,28 @@ type ChatParticipant struct {
 
 // Message represents a single message in a chat
 type Message struct {
-	ID           uuid.UUID     `db:"m_id"`
-	ChatID       uuid.UUID     `db:"chat_id"`
-	SenderID     uuid.UUID     `db:"sender_id"`
-	Type         MessageType   `db:"type"`
-	Content      string        `db:"content"`
-	Status       MessageStatus `db:"status"`
-	CreatedAt    time.Time     `db:"created_at"`
-	UpdatedAt    time.Time     `db:"updated_at"`
-	DeletedAt    *time.Time    `db:"delete_at"`
-	UserID       uuid.UUID     `db:"u_id"`
-	Email        string        `db:"email"`
-	FirstName    string        `db:"first_name"`
-	LastName     string        `db:"last_name"`
-	Phone        string        `db:"phone"`
-	PlayLevel    string        `db:"play_level"`
-	AvatarURL    *string        `db:"avatar_url"`
-	Gender       *string       `db:"gender"`
-	Location     *string       `db:"location"`
-	Bio          *string        `db:"bio"`
-	LastActiveAt time.Time     `db:"last_active_at"`
+	ID        uuid.UUID     `db:"m_id"`
+	ChatID    uuid.UUID     `db:"chat_id"`
+	SenderID  uuid.UUID     `db:"sender_id"`
+	Type      MessageType   `db:"type"`
+	Content   string        `db:"content"`
+	Status    MessageStatus `db:"status"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+	DeletedAt *time.Time    `db:"delete_at"`
+	// ClientMessageID is the id the sender gave the message, unique per chat and sender
+	ClientMessageID *string   `db:"client_message_id"`
+	UserID          uuid.UUID `db:"u_id"`
+	Email           string    `db:"email"`
+	FirstName       string    `db:"first_name"`
+	LastName        string    `db:"last_name"`
+	Phone           string    `db:"phone"`
+	PlayLevel       string    `db:"play_level"`
+	AvatarURL       *string   `db:"avatar_url"`
+	Gender          *string   `db:"gender"`
+	Location        *string   `db:"location"`
+	Bio             *string   `db:"bio"`
+	LastActiveAt    time.Time `db:"last_active_at"`
 
 	// Populated fields
 	// Sender *User       `db:"sender,omitempty"`
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConnectionStatus string
+
+const (
+	ConnectionStatusPending  ConnectionStatus = "pending"
+	ConnectionStatusAccepted ConnectionStatus = "accepted"
+)
+
+// PlayerConnection links two players. Player1 is the one who sent the request.
+type PlayerConnection struct {
+	ID        uuid.UUID        `db:"id"`
+	Player1ID uuid.UUID        `db:"player1_id"`
+	Player2ID uuid.UUID        `db:"player2_id"`
+	Status    ConnectionStatus `db:"status"`
+	CreatedAt time.Time        `db:"created_at"`
+	UpdatedAt time.Time        `db:"updated_at"`
+
+	// Joined fields describing the other player from the caller's point of view
+	OtherUserID    uuid.UUID   `db:"other_user_id"`
+	OtherFirstName string      `db:"other_first_name"`
+	OtherLastName  string      `db:"other_last_name"`
+	OtherAvatarURL string      `db:"other_avatar_url"`
+	OtherPlayLevel PlayerLevel `db:"other_play_level"`
+}
+
+// PlayingPartner is a player who shared confirmed sessions with a user
+type PlayingPartner struct {
+	UserID           uuid.UUID   `db:"user_id"`
+	FirstName        string      `db:"first_name"`
+	LastName         string      `db:"last_name"`
+	AvatarURL        string      `db:"avatar_url"`
+	PlayLevel        PlayerLevel `db:"play_level"`
+	SessionsTogether int         `db:"sessions_together"`
+	LastPlayedAt     time.Time   `db:"last_played_at"`
+}
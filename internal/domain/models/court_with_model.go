@@ -6,3 +6,17 @@ type CourtWithVenue struct {
 	VenueLocation string `db:"venue_location"`
 	VenueStatus   string `db:"venue_status"`
 }
+
+// CourtSearchResult is a court found by a search across venues, with the details of its
+// venue the search needs
+type CourtSearchResult struct {
+	Court
+	VenueAddress   string         `db:"venue_address"`
+	VenueOpenRange NullRawMessage `db:"venue_open_range"`
+	VenueRating    float64        `db:"venue_rating"`
+	VenueLatitude  float64        `db:"venue_latitude"`
+	VenueLongitude float64        `db:"venue_longitude"`
+
+	// DistanceKm is how far the venue is from the searched point, when one was given
+	DistanceKm *float64 `db:"distance_km"`
+}
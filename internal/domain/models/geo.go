@@ -0,0 +1,53 @@
+package models
+
+import "fmt"
+
+// GeoQuery narrows a venue search to a radius around a point or to a bounding box on a map.
+// When a point is given, results are ordered by their distance from it.
+type GeoQuery struct {
+	Latitude  *float64
+	Longitude *float64
+	RadiusKm  float64
+	Bounds    *GeoBounds
+}
+
+// GeoBounds is a bounding box from its south-west to its north-east corner
+type GeoBounds struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// HasPoint reports whether the query is around a point
+func (q *GeoQuery) HasPoint() bool {
+	return q != nil && q.Latitude != nil && q.Longitude != nil
+}
+
+// Validate checks that the point, radius and bounds are complete and in range
+func (q *GeoQuery) Validate() error {
+	if (q.Latitude == nil) != (q.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be given together")
+	}
+	if q.HasPoint() {
+		if *q.Latitude < -90 || *q.Latitude > 90 || *q.Longitude < -180 || *q.Longitude > 180 {
+			return fmt.Errorf("coordinates are out of range")
+		}
+	}
+	if q.RadiusKm < 0 {
+		return fmt.Errorf("radius must not be negative")
+	}
+	if q.RadiusKm > 0 && !q.HasPoint() {
+		return fmt.Errorf("a radius needs a latitude and longitude")
+	}
+
+	if b := q.Bounds; b != nil {
+		if b.MinLatitude > b.MaxLatitude || b.MinLongitude > b.MaxLongitude {
+			return fmt.Errorf("bounding box corners must be south-west then north-east")
+		}
+		if b.MinLatitude < -90 || b.MaxLatitude > 90 || b.MinLongitude < -180 || b.MaxLongitude > 180 {
+			return fmt.Errorf("bounding box is out of range")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/google/uuid"
+)
+
+// MaxMaintenanceDuration bounds a single maintenance window so it cannot hide a court indefinitely
+const MaxMaintenanceDuration = 30 * 24 * time.Hour
+
+// MaintenanceWindow takes a court out of use between two moments. Bookings and sessions
+// cannot use the court in the window, and the court shows as under maintenance during it.
+type MaintenanceWindow struct {
+	ID        uuid.UUID `db:"id"`
+	CourtID   uuid.UUID `db:"court_id"`
+	StartsAt  time.Time `db:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"`
+	Reason    *string   `db:"reason"`
+	CreatedBy uuid.UUID `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+
+	// Joined fields
+	CourtName string `db:"court_name"`
+}
+
+// Validate checks that the window is in order and not too long
+func (w *MaintenanceWindow) Validate() error {
+	if !w.EndsAt.After(w.StartsAt) {
+		return fmt.Errorf("end must be after the start")
+	}
+	if w.EndsAt.Sub(w.StartsAt) > MaxMaintenanceDuration {
+		return fmt.Errorf("maintenance cannot last longer than %d days", int(MaxMaintenanceDuration.Hours()/24))
+	}
+	return nil
+}
+
+// ActiveAt reports whether the court is under maintenance at a moment
+func (w *MaintenanceWindow) ActiveAt(t time.Time) bool {
+	return !t.Before(w.StartsAt) && t.Before(w.EndsAt)
+}
+
+// ApplyMaintenance closes the parts of the schedule's date that maintenance windows cover.
+// The date is read in the booking time zone.
+func (d *DaySchedule) ApplyMaintenance(date time.Time, windows []MaintenanceWindow) {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, BookingTimeZone)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	for _, window := range windows {
+		if !window.StartsAt.Before(dayEnd) || !window.EndsAt.After(dayStart) {
+			continue
+		}
+
+		reason := "court is under maintenance"
+		if window.Reason != nil && *window.Reason != "" {
+			reason = fmt.Sprintf("court is under maintenance (%s)", *window.Reason)
+		}
+
+		start, end := 0, minutesPerDay
+		if window.StartsAt.After(dayStart) {
+			start = int(window.StartsAt.Sub(dayStart).Minutes())
+		}
+		if window.EndsAt.Before(dayEnd) {
+			end = int(window.EndsAt.Sub(dayStart).Minutes())
+		}
+
+		d.Closures = append(d.Closures, Closure{StartMinute: start, EndMinute: end, Reason: reason, Maintenance: true})
+	}
+}
+
+// ToResponse converts the maintenance window to a response DTO
+func (w *MaintenanceWindow) ToResponse() *responses.MaintenanceWindowResponse {
+	resp := &responses.MaintenanceWindowResponse{
+		ID:        w.ID.String(),
+		CourtID:   w.CourtID.String(),
+		CourtName: w.CourtName,
+		StartsAt:  w.StartsAt.Format(time.RFC3339),
+		EndsAt:    w.EndsAt.Format(time.RFC3339),
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+
+	if w.Reason != nil {
+		resp.Reason = *w.Reason
+	}
+
+	return resp
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlayerReview is a rating one participant gives another after a session
+type PlayerReview struct {
+	ID         uuid.UUID `db:"id"`
+	ReviewerID uuid.UUID `db:"reviewer_id"`
+	ReviewedID uuid.UUID `db:"reviewed_id"`
+	SessionID  uuid.UUID `db:"session_id"`
+	Rating     int       `db:"rating"`
+	Comment    *string   `db:"comment"`
+	CreatedAt  time.Time `db:"created_at"`
+
+	// Joined fields
+	ReviewerFirstName string    `db:"reviewer_first_name"`
+	ReviewerLastName  string    `db:"reviewer_last_name"`
+	ReviewerAvatarURL string    `db:"reviewer_avatar_url"`
+	ReviewedFirstName string    `db:"reviewed_first_name"`
+	ReviewedLastName  string    `db:"reviewed_last_name"`
+	ReviewedAvatarURL string    `db:"reviewed_avatar_url"`
+	SessionTitle      string    `db:"session_title"`
+	SessionDate       time.Time `db:"session_date"`
+}
+
+// PlayerRatingSummary aggregates the reviews a player has received
+type PlayerRatingSummary struct {
+	AverageRating float64 `db:"avg_rating"`
+	TotalReviews  int     `db:"total_reviews"`
+}
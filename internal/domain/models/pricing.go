@@ -0,0 +1,246 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/google/uuid"
+)
+
+// PricingRule replaces a court's price per hour inside a daily time window. A rule
+// applies on its weekdays, or only on the venue's holidays when HolidaysOnly is set,
+// and can be limited to a date range and to a single court.
+type PricingRule struct {
+	ID           uuid.UUID  `db:"id"`
+	VenueID      uuid.UUID  `db:"venue_id"`
+	CourtID      *uuid.UUID `db:"court_id"`
+	Name         string     `db:"name"`
+	Weekdays     WeekdaySet `db:"weekdays"`
+	HolidaysOnly bool       `db:"holidays_only"`
+	StartTime    time.Time  `db:"start_time"`
+	EndTime      time.Time  `db:"end_time"`
+	StartDate    *time.Time `db:"start_date"`
+	EndDate      *time.Time `db:"end_date"`
+	PricePerHour float64    `db:"price_per_hour"`
+	Priority     int        `db:"priority"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// Holiday is a date on a venue's holiday calendar
+type Holiday struct {
+	ID        uuid.UUID `db:"id"`
+	VenueID   uuid.UUID `db:"venue_id"`
+	Date      time.Time `db:"holiday_date"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Validate checks the rule's window, dates and price
+func (r *PricingRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !r.HolidaysOnly && r.Weekdays <= 0 {
+		return fmt.Errorf("at least one weekday is required unless the rule is for holidays")
+	}
+	if minuteOfDay(r.StartTime) >= minuteOfDay(r.EndTime) {
+		return fmt.Errorf("start time must be before end time")
+	}
+	if r.StartDate != nil && r.EndDate != nil && r.EndDate.Before(*r.StartDate) {
+		return fmt.Errorf("end date must not be before the start date")
+	}
+	if r.PricePerHour <= 0 {
+		return fmt.Errorf("price per hour must be greater than 0")
+	}
+	return nil
+}
+
+// appliesOn reports whether the rule is in effect for a court on a date
+func (r *PricingRule) appliesOn(courtID uuid.UUID, date time.Time, holiday bool) bool {
+	if r.CourtID != nil && *r.CourtID != courtID {
+		return false
+	}
+
+	day := civilDate(date)
+	if r.StartDate != nil && day.Before(civilDate(*r.StartDate)) {
+		return false
+	}
+	if r.EndDate != nil && day.After(civilDate(*r.EndDate)) {
+		return false
+	}
+
+	if r.HolidaysOnly {
+		return holiday
+	}
+	return r.Weekdays.Has(date.Weekday())
+}
+
+// outranks reports whether the rule wins over another rule covering the same time.
+// Higher priority wins, then holiday rules, then rules for a single court.
+func (r *PricingRule) outranks(other *PricingRule) bool {
+	if r.Priority != other.Priority {
+		return r.Priority > other.Priority
+	}
+	if r.HolidaysOnly != other.HolidaysOnly {
+		return r.HolidaysOnly
+	}
+	return r.CourtID != nil && other.CourtID == nil
+}
+
+// VenuePricing holds the pricing rules of a venue and whether a given date is one of its holidays
+type VenuePricing struct {
+	Rules   []PricingRule
+	Holiday bool
+}
+
+// PriceSegment is a stretch of a booking charged at a single price per hour
+type PriceSegment struct {
+	StartTime    time.Time
+	EndTime      time.Time
+	PricePerHour float64
+	RuleID       *uuid.UUID
+}
+
+// Amount is the price of the segment
+func (s PriceSegment) Amount() float64 {
+	return s.EndTime.Sub(s.StartTime).Hours() * s.PricePerHour
+}
+
+// Price splits a booking of a court into segments at every rule boundary it crosses and
+// prices each with the winning rule, or the court's base price where no rule applies.
+func (p *VenuePricing) Price(courtID uuid.UUID, basePrice float64, date, startTime, endTime time.Time) (float64, []PriceSegment) {
+	start, end := minuteOfDay(startTime), minuteOfDay(endTime)
+
+	rules := []*PricingRule{}
+	if p != nil {
+		for i := range p.Rules {
+			if p.Rules[i].appliesOn(courtID, date, p.Holiday) {
+				rules = append(rules, &p.Rules[i])
+			}
+		}
+	}
+
+	bounds := []int{start, end}
+	for _, rule := range rules {
+		for _, bound := range []int{minuteOfDay(rule.StartTime), minuteOfDay(rule.EndTime)} {
+			if bound > start && bound < end {
+				bounds = append(bounds, bound)
+			}
+		}
+	}
+	sort.Ints(bounds)
+
+	var total float64
+	segments := []PriceSegment{}
+	for i := 0; i+1 < len(bounds); i++ {
+		from, to := bounds[i], bounds[i+1]
+		if from == to {
+			continue
+		}
+
+		var winner *PricingRule
+		for _, rule := range rules {
+			if minuteOfDay(rule.StartTime) <= from && to <= minuteOfDay(rule.EndTime) {
+				if winner == nil || rule.outranks(winner) {
+					winner = rule
+				}
+			}
+		}
+
+		segment := PriceSegment{
+			StartTime:    startTime.Add(time.Duration(from-start) * time.Minute),
+			EndTime:      startTime.Add(time.Duration(to-start) * time.Minute),
+			PricePerHour: basePrice,
+		}
+		if winner != nil {
+			segment.PricePerHour = winner.PricePerHour
+			segment.RuleID = &winner.ID
+		}
+
+		// Merge neighbours charged the same way so the breakdown stays readable
+		if last := len(segments) - 1; last >= 0 && segments[last].PricePerHour == segment.PricePerHour && sameRule(segments[last].RuleID, segment.RuleID) {
+			segments[last].EndTime = segment.EndTime
+		} else {
+			segments = append(segments, segment)
+		}
+		total += segment.Amount()
+	}
+
+	return math.Round(total*100) / 100, segments
+}
+
+func sameRule(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// minuteOfDay returns the minutes since midnight of a time of day
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// civilDate drops the time of day and zone so dates from different sources compare cleanly
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ToResponse converts the rule to a response DTO
+func (r *PricingRule) ToResponse() *responses.PricingRuleResponse {
+	resp := &responses.PricingRuleResponse{
+		ID:           r.ID.String(),
+		VenueID:      r.VenueID.String(),
+		Name:         r.Name,
+		Weekdays:     r.Weekdays.Names(),
+		HolidaysOnly: r.HolidaysOnly,
+		StartTime:    r.StartTime.Format("15:04"),
+		EndTime:      r.EndTime.Format("15:04"),
+		PricePerHour: r.PricePerHour,
+		Priority:     r.Priority,
+		CreatedAt:    r.CreatedAt.Format(time.RFC3339),
+	}
+
+	if r.CourtID != nil {
+		resp.CourtID = r.CourtID.String()
+	}
+
+	if r.StartDate != nil {
+		resp.StartDate = r.StartDate.Format("2006-01-02")
+	}
+
+	if r.EndDate != nil {
+		resp.EndDate = r.EndDate.Format("2006-01-02")
+	}
+
+	return resp
+}
+
+// ToResponse converts the holiday to a response DTO
+func (h *Holiday) ToResponse() *responses.HolidayResponse {
+	return &responses.HolidayResponse{
+		ID:   h.ID.String(),
+		Date: h.Date.Format("2006-01-02"),
+		Name: h.Name,
+	}
+}
+
+// ToResponse converts the segment to a response DTO
+func (s PriceSegment) ToResponse() responses.PriceSegmentResponse {
+	resp := responses.PriceSegmentResponse{
+		StartTime:    s.StartTime.Format("15:04"),
+		EndTime:      s.EndTime.Format("15:04"),
+		PricePerHour: s.PricePerHour,
+		Amount:       math.Round(s.Amount()*100) / 100,
+	}
+
+	if s.RuleID != nil {
+		resp.RuleID = s.RuleID.String()
+	}
+
+	return resp
+}
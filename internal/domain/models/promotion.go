@@ -0,0 +1,216 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+// UUIDList stores a list of IDs in a Postgres uuid[] column. A nil list is stored as NULL.
+type UUIDList []uuid.UUID
+
+// Value implements the driver.Valuer interface
+func (l UUIDList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	ids := make(pq.StringArray, len(l))
+	for i, id := range l {
+		ids[i] = id.String()
+	}
+	return ids.Value()
+}
+
+// Scan implements the sql.Scanner interface
+func (l *UUIDList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	var ids pq.StringArray
+	if err := ids.Scan(value); err != nil {
+		return err
+	}
+
+	list := make(UUIDList, len(ids))
+	for i, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("invalid uuid in list: %w", err)
+		}
+		list[i] = parsed
+	}
+	*l = list
+	return nil
+}
+
+// Promotion is a discount code for court bookings. A promotion without a venue is run by
+// the platform and applies at every venue; CourtIDs narrows a venue's promotion to some courts.
+type Promotion struct {
+	ID             uuid.UUID    `db:"id"`
+	Code           string       `db:"code"`
+	Description    *string      `db:"description"`
+	VenueID        *uuid.UUID   `db:"venue_id"`
+	CourtIDs       UUIDList     `db:"court_ids"`
+	DiscountType   DiscountType `db:"discount_type"`
+	DiscountValue  float64      `db:"discount_value"`
+	MinSpend       float64      `db:"min_spend"`
+	MaxUses        *int         `db:"max_uses"`
+	MaxUsesPerUser *int         `db:"max_uses_per_user"`
+	UsesCount      int          `db:"uses_count"`
+	StartsAt       time.Time    `db:"starts_at"`
+	EndsAt         time.Time    `db:"ends_at"`
+	Active         bool         `db:"active"`
+	CreatedBy      uuid.UUID    `db:"created_by"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+}
+
+// PromotionRedemption records a promotion code used on a booking
+type PromotionRedemption struct {
+	ID             uuid.UUID `db:"id"`
+	PromotionID    uuid.UUID `db:"promotion_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	BookingID      uuid.UUID `db:"booking_id"`
+	DiscountAmount float64   `db:"discount_amount"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// NormalizePromotionCode puts a code in the form it is stored and matched in
+func NormalizePromotionCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the promotion's discount, limits and validity window
+func (p *Promotion) Validate() error {
+	if p.Code == "" {
+		return fmt.Errorf("code is required")
+	}
+
+	switch p.DiscountType {
+	case DiscountTypePercentage:
+		if p.DiscountValue <= 0 || p.DiscountValue >= 100 {
+			return fmt.Errorf("percentage discount must be between 0 and 100")
+		}
+	case DiscountTypeFixed:
+		if p.DiscountValue <= 0 {
+			return fmt.Errorf("fixed discount must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("invalid discount type: %s", p.DiscountType)
+	}
+
+	if p.MinSpend < 0 {
+		return fmt.Errorf("minimum spend must not be negative")
+	}
+	if p.MaxUses != nil && *p.MaxUses <= 0 {
+		return fmt.Errorf("max uses must be greater than 0")
+	}
+	if p.MaxUsesPerUser != nil && *p.MaxUsesPerUser <= 0 {
+		return fmt.Errorf("max uses per user must be greater than 0")
+	}
+	if len(p.CourtIDs) > 0 && p.VenueID == nil {
+		return fmt.Errorf("courts can only be restricted for a venue promotion")
+	}
+	if !p.EndsAt.After(p.StartsAt) {
+		return fmt.Errorf("end must be after the start")
+	}
+	return nil
+}
+
+// CheckApplicable reports why the promotion cannot be used on a booking of a court
+// for the given amount, or nil if it can. Usage caps are checked by the caller.
+func (p *Promotion) CheckApplicable(now time.Time, venueID, courtID uuid.UUID, amount float64) error {
+	if !p.Active {
+		return fmt.Errorf("promotion is no longer active")
+	}
+	if now.Before(p.StartsAt) {
+		return fmt.Errorf("promotion has not started yet")
+	}
+	if !now.Before(p.EndsAt) {
+		return fmt.Errorf("promotion has expired")
+	}
+	if p.VenueID != nil && *p.VenueID != venueID {
+		return fmt.Errorf("promotion is not valid at this venue")
+	}
+	if len(p.CourtIDs) > 0 && !p.appliesToCourt(courtID) {
+		return fmt.Errorf("promotion is not valid for this court")
+	}
+	if amount < p.MinSpend {
+		return fmt.Errorf("booking amount is below the minimum spend of %.2f", p.MinSpend)
+	}
+	// Bookings are only confirmed through a payment, so something must be left to pay
+	if p.Discount(amount) >= amount {
+		return fmt.Errorf("promotion cannot cover the whole booking amount")
+	}
+	return nil
+}
+
+func (p *Promotion) appliesToCourt(courtID uuid.UUID) bool {
+	for _, id := range p.CourtIDs {
+		if id == courtID {
+			return true
+		}
+	}
+	return false
+}
+
+// Discount is how much the promotion takes off an amount, rounded to satang and never
+// more than the amount itself
+func (p *Promotion) Discount(amount float64) float64 {
+	discount := p.DiscountValue
+	if p.DiscountType == DiscountTypePercentage {
+		discount = amount * p.DiscountValue / 100
+	}
+
+	return math.Round(math.Min(discount, amount)*100) / 100
+}
+
+// ToResponse converts the promotion to a response DTO
+func (p *Promotion) ToResponse() *responses.PromotionResponse {
+	resp := &responses.PromotionResponse{
+		ID:             p.ID.String(),
+		Code:           p.Code,
+		DiscountType:   string(p.DiscountType),
+		DiscountValue:  p.DiscountValue,
+		MinSpend:       p.MinSpend,
+		MaxUses:        p.MaxUses,
+		MaxUsesPerUser: p.MaxUsesPerUser,
+		UsesCount:      p.UsesCount,
+		StartsAt:       p.StartsAt.Format(time.RFC3339),
+		EndsAt:         p.EndsAt.Format(time.RFC3339),
+		Active:         p.Active,
+		CourtIDs:       make([]string, len(p.CourtIDs)),
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+	}
+
+	if p.Description != nil {
+		resp.Description = *p.Description
+	}
+
+	if p.VenueID != nil {
+		resp.VenueID = p.VenueID.String()
+	}
+
+	for i, id := range p.CourtIDs {
+		resp.CourtIDs[i] = id.String()
+	}
+
+	return resp
+}
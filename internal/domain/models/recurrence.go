@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WeekdaySet is a set of weekdays stored as a bitmask, bit 0 being Sunday
+type WeekdaySet int
+
+// ParseWeekdays builds a set from weekday names such as "tuesday"
+func ParseWeekdays(names []string) (WeekdaySet, error) {
+	var set WeekdaySet
+	for _, name := range names {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(name, day.String()) {
+				set |= 1 << day
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid weekday %q", name)
+		}
+	}
+	return set, nil
+}
+
+func (s WeekdaySet) Has(day time.Weekday) bool {
+	return s&(1<<day) != 0
+}
+
+// Names lists the weekdays in the set, starting from Sunday
+func (s WeekdaySet) Names() []string {
+	names := []string{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if s.Has(day) {
+			names = append(names, strings.ToLower(day.String()))
+		}
+	}
+	return names
+}
+
+// WeeklyDates lists the dates falling on the given weekdays from start onwards, up to
+// until when set and at most limit dates. The week of start counts as the first week,
+// and weeks then repeat every intervalWeeks.
+func WeeklyDates(start time.Time, until *time.Time, weekdays WeekdaySet, intervalWeeks, limit int) []time.Time {
+	if intervalWeeks < 1 {
+		intervalWeeks = 1
+	}
+
+	weekStart := start.AddDate(0, 0, -int(start.Weekday()))
+	dates := []time.Time{}
+	for week := 0; len(dates) < limit && week <= limit*intervalWeeks; week += intervalWeeks {
+		for day := time.Sunday; day <= time.Saturday && len(dates) < limit; day++ {
+			if !weekdays.Has(day) {
+				continue
+			}
+
+			date := weekStart.AddDate(0, 0, week*7+int(day))
+			if date.Before(start) {
+				continue
+			}
+			if until != nil && date.After(*until) {
+				return dates
+			}
+
+			dates = append(dates, date)
+		}
+	}
+
+	return dates
+}
@@ -0,0 +1,280 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleOverride changes a venue's opening hours on a single date, for the whole venue or
+// for one court. A closed override shuts the venue or court between StartTime and EndTime,
+// or all day when they are not set. Otherwise StartTime and EndTime are the day's opening
+// hours, replacing the weekly ones for the venue or narrowing them for a court.
+type ScheduleOverride struct {
+	ID        uuid.UUID  `db:"id"`
+	VenueID   uuid.UUID  `db:"venue_id"`
+	CourtID   *uuid.UUID `db:"court_id"`
+	Date      time.Time  `db:"override_date"`
+	Closed    bool       `db:"closed"`
+	StartTime *time.Time `db:"start_time"`
+	EndTime   *time.Time `db:"end_time"`
+	Reason    *string    `db:"reason"`
+	CreatedBy uuid.UUID  `db:"created_by"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// Validate checks that the override's window is complete and in order
+func (o *ScheduleOverride) Validate() error {
+	if (o.StartTime == nil) != (o.EndTime == nil) {
+		return fmt.Errorf("start and end time must be given together")
+	}
+	if o.StartTime == nil && !o.Closed {
+		return fmt.Errorf("opening hours are required unless the override closes the whole day")
+	}
+	if o.StartTime != nil && minuteOfDay(*o.StartTime) >= minuteOfDay(*o.EndTime) {
+		return fmt.Errorf("start time must be before end time")
+	}
+	return nil
+}
+
+// AllDay reports whether the override covers the whole day
+func (o *ScheduleOverride) AllDay() bool {
+	return o.StartTime == nil
+}
+
+func (o *ScheduleOverride) reason() string {
+	if o.Reason != nil && *o.Reason != "" {
+		return *o.Reason
+	}
+	return "closed"
+}
+
+// minutesPerDay lets a schedule run until midnight, which a time of day cannot express
+const minutesPerDay = 24 * 60
+
+// DaySchedule is when a court can be booked on a given date. Times are minutes since midnight.
+type DaySchedule struct {
+	Open        bool
+	OpenMinute  int
+	CloseMinute int
+	// Reason explains why the day is closed when it comes from an override
+	Reason   string
+	Closures []Closure
+}
+
+// Closure is a stretch of a day in which a court cannot be booked
+type Closure struct {
+	StartMinute int
+	EndMinute   int
+	Reason      string
+	Maintenance bool
+}
+
+// SlotStatus is the state of a slot in the availability grid
+type SlotStatus string
+
+const (
+	SlotStatusFree        SlotStatus = "free"
+	SlotStatusBooked      SlotStatus = "booked"
+	SlotStatusMaintenance SlotStatus = "maintenance"
+	SlotStatusClosed      SlotStatus = "closed"
+)
+
+// AllDaySchedule is open around the clock, for checks that only care about overrides
+func AllDaySchedule() *DaySchedule {
+	return &DaySchedule{Open: true, CloseMinute: minutesPerDay}
+}
+
+// WeeklySchedule reads the venue's regular opening hours for the weekday of a date
+func WeeklySchedule(openRange NullRawMessage, date time.Time) (*DaySchedule, error) {
+	if !openRange.Valid {
+		return nil, fmt.Errorf("venue open range is invalid")
+	}
+
+	var openRanges []responses.OpenRangeResponse
+	if err := json.Unmarshal(openRange.RawMessage, &openRanges); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal open range: %w", err)
+	}
+
+	for _, schedule := range openRanges {
+		if strings.EqualFold(schedule.Day, date.Weekday().String()) {
+			return &DaySchedule{
+				Open:        schedule.IsOpen,
+				OpenMinute:  minuteOfDay(schedule.OpenTime),
+				CloseMinute: minuteOfDay(schedule.CloseTime),
+				Reason:      fmt.Sprintf("venue is closed on %s", date.Weekday()),
+			}, nil
+		}
+	}
+
+	return &DaySchedule{Reason: fmt.Sprintf("venue is closed on %s", date.Weekday())}, nil
+}
+
+// ApplyOverrides adjusts the schedule with the overrides of its date that apply to a court.
+// Venue hours are applied first and court hours narrow them; closures win over both.
+func (d *DaySchedule) ApplyOverrides(overrides []ScheduleOverride, courtID uuid.UUID) {
+	applies := func(o *ScheduleOverride) bool {
+		return o.CourtID == nil || *o.CourtID == courtID
+	}
+
+	for i := range overrides {
+		o := &overrides[i]
+		if applies(o) && !o.Closed && o.CourtID == nil {
+			d.Open = true
+			d.OpenMinute, d.CloseMinute = minuteOfDay(*o.StartTime), minuteOfDay(*o.EndTime)
+		}
+	}
+
+	for i := range overrides {
+		o := &overrides[i]
+		if applies(o) && !o.Closed && o.CourtID != nil {
+			d.OpenMinute = max(d.OpenMinute, minuteOfDay(*o.StartTime))
+			d.CloseMinute = min(d.CloseMinute, minuteOfDay(*o.EndTime))
+		}
+	}
+
+	for i := range overrides {
+		o := &overrides[i]
+		if !applies(o) || !o.Closed {
+			continue
+		}
+		if o.AllDay() {
+			d.Open = false
+			d.Reason = o.reason()
+		} else {
+			d.Closures = append(d.Closures, Closure{
+				StartMinute: minuteOfDay(*o.StartTime),
+				EndMinute:   minuteOfDay(*o.EndTime),
+				Reason:      o.reason(),
+			})
+		}
+	}
+}
+
+// Check explains why a slot cannot be booked under the schedule, or returns nil if it can
+func (d *DaySchedule) Check(startTime, endTime time.Time) error {
+	if !d.Open || d.OpenMinute >= d.CloseMinute {
+		if d.Reason == "" {
+			return fmt.Errorf("court is closed on this date")
+		}
+		return fmt.Errorf("%s", d.Reason)
+	}
+
+	start, end := slotMinutes(startTime, endTime)
+	if start < d.OpenMinute || end > d.CloseMinute {
+		return fmt.Errorf("booking must be within venue operating hours (%s - %s)",
+			formatMinute(d.OpenMinute), formatMinute(d.CloseMinute))
+	}
+
+	for _, closure := range d.Closures {
+		if start < closure.EndMinute && closure.StartMinute < end {
+			return fmt.Errorf("%s from %s to %s", closure.Reason,
+				formatMinute(closure.StartMinute), formatMinute(closure.EndMinute))
+		}
+	}
+
+	return nil
+}
+
+// SlotStatus tells whether the schedule leaves a slot free, closes it or has the court under
+// maintenance. Bookings are not part of the schedule and are up to the caller.
+func (d *DaySchedule) SlotStatus(startTime, endTime time.Time) SlotStatus {
+	if d.Check(startTime, endTime) == nil {
+		return SlotStatusFree
+	}
+
+	start, end := slotMinutes(startTime, endTime)
+	if !d.Open || start < d.OpenMinute || end > d.CloseMinute {
+		return SlotStatusClosed
+	}
+
+	status := SlotStatusClosed
+	for _, closure := range d.Closures {
+		if start < closure.EndMinute && closure.StartMinute < end {
+			if !closure.Maintenance {
+				return SlotStatusClosed
+			}
+			status = SlotStatusMaintenance
+		}
+	}
+	return status
+}
+
+// Hours returns the opening and closing time of the day as "15:04"
+func (d *DaySchedule) Hours() (string, string) {
+	return formatMinute(d.OpenMinute), formatMinute(d.CloseMinute)
+}
+
+// HalfHours lists every half-hour slot from opening to closing time, whether it can be booked or not
+func (d *DaySchedule) HalfHours() [][2]time.Time {
+	slots := [][2]time.Time{}
+	if !d.Open {
+		return slots
+	}
+
+	for m := d.OpenMinute; m+30 <= d.CloseMinute; m += 30 {
+		slots = append(slots, [2]time.Time{clockTime(m), clockTime(m + 30)})
+	}
+	return slots
+}
+
+// Slots lists the half-hour slots from opening to closing time that the schedule allows
+func (d *DaySchedule) Slots() [][2]time.Time {
+	slots := [][2]time.Time{}
+	for _, slot := range d.HalfHours() {
+		if d.Check(slot[0], slot[1]) == nil {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// slotMinutes turns a slot's times into minutes since midnight. A slot ending at midnight
+// wraps to 00:00, so it is read as the end of the day.
+func slotMinutes(startTime, endTime time.Time) (int, int) {
+	start, end := minuteOfDay(startTime), minuteOfDay(endTime)
+	if end == 0 {
+		end = minutesPerDay
+	}
+	return start, end
+}
+
+// clockTime turns minutes since midnight into a time of day like those parsed from "15:04"
+func clockTime(minute int) time.Time {
+	return time.Date(0, 1, 1, 0, minute, 0, 0, time.UTC)
+}
+
+func formatMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// ToResponse converts the override to a response DTO
+func (o *ScheduleOverride) ToResponse() *responses.ScheduleOverrideResponse {
+	resp := &responses.ScheduleOverrideResponse{
+		ID:        o.ID.String(),
+		VenueID:   o.VenueID.String(),
+		Date:      o.Date.Format("2006-01-02"),
+		Closed:    o.Closed,
+		CreatedAt: o.CreatedAt.Format(time.RFC3339),
+	}
+
+	if o.CourtID != nil {
+		resp.CourtID = o.CourtID.String()
+	}
+
+	if o.StartTime != nil {
+		resp.StartTime = o.StartTime.Format("15:04")
+		resp.EndTime = o.EndTime.Format("15:04")
+	}
+
+	if o.Reason != nil {
+		resp.Reason = *o.Reason
+	}
+
+	return resp
+}
@@ -18,6 +18,8 @@ const (
 	ParticipantStatusConfirmed ParticipantStatus = "confirmed"
 	ParticipantStatusPending   ParticipantStatus = "pending"
 	ParticipantStatusCancelled ParticipantStatus = "cancelled"
+	// ParticipantStatusWaitlisted queues a player for the next spot freed in a full session
+	ParticipantStatusWaitlisted ParticipantStatus = "waitlisted"
 )
 
 // Session represents a play session
@@ -37,6 +39,7 @@ type Session struct {
 	CancellationDeadlineHours *int          `db:"cancellation_deadline_hours"`
 	IsPublic                  bool          `db:"is_public"`
 	Status                    SessionStatus `db:"status"`
+	SeriesID                  *uuid.UUID    `db:"series_id"`
 	CreatedAt                 time.Time     `db:"created_at"`
 	UpdatedAt                 time.Time     `db:"updated_at"`
 }
@@ -61,7 +64,14 @@ type SessionParticipant struct {
 	PlayerLevel PlayerLevel       `db:"player_level,"`       // From JOIN with users table
 }
 
-// Court represents a court at a venue
+// SessionCourt represents a court reserved for a session
+type SessionCourt struct {
+	ID        uuid.UUID `db:"id"`
+	SessionID uuid.UUID `db:"session_id"`
+	CourtID   uuid.UUID `db:"court_id"`
+	CourtName string    `db:"court_name"`
+	CreatedAt time.Time `db:"created_at"`
+}
 
 // SessionDetail represents a session with additional details
 type SessionDetail struct {
@@ -73,6 +83,7 @@ type SessionDetail struct {
 	ConfirmedPlayers int                  `db:"confirmed_players"`
 	Participants     []SessionParticipant `db:"participants,omitempty"`
 	Rules            []SessionRule        `db:"rules,omitempty"`
+	Courts           []SessionCourt       `db:"courts,omitempty"`
 	Search_vector    string               `db:"search_vector"`
 	IsPublic         bool                 `db:"is_public"`
 }
@@ -88,5 +99,7 @@ const (
 
 	JoinStatusCancelled JoinStatus = "cancelled"
 
+	JoinStatusWaitlisted JoinStatus = "waitlisted"
+
 	JoinStatusNotJoined JoinStatus = "not_joined"
 )
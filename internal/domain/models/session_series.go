@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxSeriesOccurrences bounds how many sessions a single recurrence may create
+const MaxSeriesOccurrences = 52
+
+// SeriesScope selects which occurrences of a recurring session a change applies to
+type SeriesScope string
+
+const (
+	SeriesScopeThis      SeriesScope = "this"
+	SeriesScopeFollowing SeriesScope = "following"
+)
+
+// SessionSeries is a recurrence rule whose occurrences are stored as individual play sessions
+type SessionSeries struct {
+	ID              uuid.UUID  `db:"id"`
+	HostID          uuid.UUID  `db:"host_id"`
+	VenueID         uuid.UUID  `db:"venue_id"`
+	Weekdays        WeekdaySet `db:"weekdays"`
+	IntervalWeeks   int        `db:"interval_weeks"`
+	StartsOn        time.Time  `db:"starts_on"`
+	UntilDate       *time.Time `db:"until_date"`
+	OccurrenceCount *int       `db:"occurrence_count"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+// Validate checks that the rule ends and stays within MaxSeriesOccurrences
+func (s *SessionSeries) Validate() error {
+	if s.Weekdays <= 0 {
+		return fmt.Errorf("at least one weekday is required")
+	}
+	if s.IntervalWeeks < 1 {
+		return fmt.Errorf("interval must be at least 1 week")
+	}
+	if s.UntilDate == nil && s.OccurrenceCount == nil {
+		return fmt.Errorf("recurrence needs an end date or a number of occurrences")
+	}
+	if s.OccurrenceCount != nil && (*s.OccurrenceCount < 1 || *s.OccurrenceCount > MaxSeriesOccurrences) {
+		return fmt.Errorf("number of occurrences must be between 1 and %d", MaxSeriesOccurrences)
+	}
+	if s.UntilDate != nil && s.UntilDate.Before(s.StartsOn) {
+		return fmt.Errorf("end date must not be before the first session")
+	}
+
+	// Look one past the cap so an end date that is too far out is rejected, not cut short
+	dates := s.dates(MaxSeriesOccurrences + 1)
+	if len(dates) == 0 {
+		return fmt.Errorf("recurrence has no occurrences")
+	}
+	if len(dates) > MaxSeriesOccurrences {
+		return fmt.Errorf("recurrence cannot create more than %d sessions", MaxSeriesOccurrences)
+	}
+	return nil
+}
+
+// Dates lists the occurrence dates of the series, starting on StartsOn. The week
+// of StartsOn counts as the first week, and weeks then repeat every IntervalWeeks.
+func (s *SessionSeries) Dates() []time.Time {
+	return s.dates(MaxSeriesOccurrences)
+}
+
+func (s *SessionSeries) dates(limit int) []time.Time {
+	if s.OccurrenceCount != nil && *s.OccurrenceCount < limit {
+		limit = *s.OccurrenceCount
+	}
+
+	return WeeklyDates(s.StartsOn, s.UntilDate, s.Weekdays, s.IntervalWeeks, limit)
+}
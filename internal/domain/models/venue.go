@@ -21,6 +21,9 @@ const (
 	CourtStatusAvailable   CourtStatus = "available"
 	CourtStatusOccupied    CourtStatus = "occupied"
 	CourtStatusMaintenance CourtStatus = "maintenance"
+
+	// DefaultBookingHoldMinutes is how long a pending booking holds its slot unless the venue says otherwise
+	DefaultBookingHoldMinutes = 30
 )
 
 // NullRawMessage is a custom type that properly handles NULL JSON values
@@ -109,6 +112,12 @@ type Venue struct {
 	Courts        []Court        `db:"courts"`
 	Latitude      float64        `db:"latitude"`
 	Longitude     float64        `db:"longitude"`
+
+	BookingHoldMinutes int            `db:"booking_hold_minutes"`
+	CancellationPolicy NullRawMessage `db:"cancellation_policy"`
+
+	// DistanceKm is set by geo searches to the distance from the searched point
+	DistanceKm *float64 `db:"distance_km"`
 }
 type VenueInsert struct {
 	ID            uuid.UUID   `db:"id"`
@@ -132,6 +141,9 @@ type VenueInsert struct {
 	Facilities    []Facility  `db:"facilities"`
 	Latitude      float64     `db:"latitude"`
 	Longitude     float64     `db:"longitude"`
+
+	BookingHoldMinutes int    `db:"booking_hold_minutes"`
+	CancellationPolicy []byte `db:"cancellation_policy"`
 }
 
 type Court struct {
@@ -149,6 +161,65 @@ type Court struct {
 	DeletedAt     *time.Time  `db:"deleted_at"`
 }
 
+// CancellationTier refunds RefundPercent of the payment when a booking is
+// cancelled more than HoursBefore hours ahead of its start
+type CancellationTier struct {
+	HoursBefore   int `json:"hours_before"`
+	RefundPercent int `json:"refund_percent"`
+}
+
+// DefaultCancellationPolicy applies to venues without their own policy
+var DefaultCancellationPolicy = []CancellationTier{
+	{HoursBefore: 24, RefundPercent: 100},
+}
+
+// CancellationTiers returns the venue's cancellation policy, or the default one if none is set
+func (v *Venue) CancellationTiers() ([]CancellationTier, error) {
+	if !v.CancellationPolicy.Valid {
+		return DefaultCancellationPolicy, nil
+	}
+
+	var tiers []CancellationTier
+	if err := json.Unmarshal(v.CancellationPolicy.RawMessage, &tiers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cancellation policy: %w", err)
+	}
+	if len(tiers) == 0 {
+		return DefaultCancellationPolicy, nil
+	}
+
+	return tiers, nil
+}
+
+// ValidateCancellationPolicy checks that every tier is in range and no two tiers share a deadline
+func ValidateCancellationPolicy(tiers []CancellationTier) error {
+	seen := make(map[int]bool, len(tiers))
+	for _, tier := range tiers {
+		if tier.HoursBefore < 0 {
+			return fmt.Errorf("hours before must not be negative")
+		}
+		if tier.RefundPercent < 0 || tier.RefundPercent > 100 {
+			return fmt.Errorf("refund percent must be between 0 and 100")
+		}
+		if seen[tier.HoursBefore] {
+			return fmt.Errorf("duplicate cancellation tier for %d hours before", tier.HoursBefore)
+		}
+		seen[tier.HoursBefore] = true
+	}
+	return nil
+}
+
+// RefundPercent returns the share of the payment refunded when cancelling the given time ahead of the start.
+// The tier with the longest deadline that has not passed yet wins; after the last deadline nothing is refunded.
+func RefundPercent(tiers []CancellationTier, ahead time.Duration) int {
+	percent, deadline := 0, -1
+	for _, tier := range tiers {
+		if ahead > time.Duration(tier.HoursBefore)*time.Hour && tier.HoursBefore > deadline {
+			percent, deadline = tier.RefundPercent, tier.HoursBefore
+		}
+	}
+	return percent
+}
+
 type VenueWithCourts struct {
 	Venue
 	Courts []Court `db:"courts"`
@@ -18,10 +18,14 @@ type Config struct {
 	SSLMode  string
 }
 
+// DSN is the connection string for the configured database
+func (c Config) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
+}
+
 func NewSQLxDB(config Config) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode)
-	db, err := sqlx.Connect("postgres", dsn)
+	db, err := sqlx.Connect("postgres", config.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to the database: %w", err)
 	}
@@ -0,0 +1,146 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeProvider is an in-process gateway for local development and tests.
+// Intents live in memory and are settled by calling Simulate, which produces
+// the same signed webhook a real gateway would send.
+type FakeProvider struct {
+	secret  string
+	baseURL string
+
+	mu      sync.Mutex
+	intents map[string]*Intent
+	refunds map[string]float64
+	// issued holds the refunds made so far by idempotency key
+	issued map[string]*Refund
+}
+
+func NewFakeProvider(secret, baseURL string) *FakeProvider {
+	return &FakeProvider{
+		secret:  secret,
+		baseURL: baseURL,
+		intents: make(map[string]*Intent),
+		refunds: make(map[string]float64),
+		issued:  make(map[string]*Refund),
+	}
+}
+
+func (p *FakeProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	intent := &Intent{
+		ID:     "fake_pi_" + uuid.NewString(),
+		Amount: req.Amount,
+		Method: req.Method,
+		Status: StatusPending,
+	}
+	intent.CheckoutURL = fmt.Sprintf("%s/api/payments/fake/%s", p.baseURL, intent.ID)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.intents[intent.ID] = intent
+
+	copied := *intent
+	return &copied, nil
+}
+
+func (p *FakeProvider) VerifyIntent(ctx context.Context, intentID string) (*Intent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return nil, ErrIntentNotFound
+	}
+
+	copied := *intent
+	return &copied, nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, intentID string, amount float64, idempotencyKey string) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if refund, ok := p.issued[idempotencyKey]; ok {
+		copied := *refund
+		return &copied, nil
+	}
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return nil, ErrIntentNotFound
+	}
+	if intent.Status != StatusSucceeded && intent.Status != StatusRefunded {
+		return nil, fmt.Errorf("%w: intent is %s", ErrInvalidRefund, intent.Status)
+	}
+	if amount <= 0 || p.refunds[intentID]+amount > intent.Amount {
+		return nil, fmt.Errorf("%w: amount exceeds what was paid", ErrInvalidRefund)
+	}
+
+	p.refunds[intentID] += amount
+	intent.Status = StatusRefunded
+
+	refund := &Refund{
+		ID:       "fake_re_" + uuid.NewString(),
+		IntentID: intentID,
+		Amount:   amount,
+	}
+	p.issued[idempotencyKey] = refund
+
+	copied := *refund
+	return &copied, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*Event, error) {
+	if err := VerifySignature(p.secret, payload, signature, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// Simulate settles an intent as the payer would and returns the signed
+// webhook payload and signature header the gateway would deliver for it
+func (p *FakeProvider) Simulate(intentID string, status Status) ([]byte, string, error) {
+	if status != StatusSucceeded && status != StatusFailed {
+		return nil, "", fmt.Errorf("can only simulate %s or %s", StatusSucceeded, StatusFailed)
+	}
+
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	if ok {
+		intent.Status = status
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		return nil, "", ErrIntentNotFound
+	}
+
+	payload, err := json.Marshal(Event{
+		ID:       "fake_evt_" + uuid.NewString(),
+		IntentID: intentID,
+		Status:   status,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, Sign(p.secret, payload, time.Now()), nil
+}
@@ -0,0 +1,81 @@
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+// Status is the state of a payment intent as reported by the gateway
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusRefunded  Status = "refunded"
+)
+
+// Method is the way the payer settles an intent
+type Method string
+
+const (
+	MethodCard Method = "card"
+	MethodQR   Method = "qr"
+)
+
+var (
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidRefund    = errors.New("invalid refund")
+)
+
+// IntentRequest describes the amount a payer is asked to pay
+type IntentRequest struct {
+	// Reference ties the intent back to our side, e.g. the booking ID
+	Reference string
+	Amount    float64
+	Currency  string
+	Method    Method
+}
+
+// Intent is a payment the gateway is collecting from the payer
+type Intent struct {
+	ID     string
+	Amount float64
+	Method Method
+	Status Status
+	// CheckoutURL is where the payer completes a card payment or scans the QR code
+	CheckoutURL string
+}
+
+// Refund is money the gateway returned to the payer
+type Refund struct {
+	ID       string
+	IntentID string
+	Amount   float64
+}
+
+// Event is a verified webhook notification about an intent
+type Event struct {
+	ID       string `json:"id"`
+	IntentID string `json:"intent_id"`
+	Status   Status `json:"status"`
+}
+
+// Provider is a payment gateway taking card and QR payments
+type Provider interface {
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	// VerifyIntent asks the gateway for the current state of an intent instead of trusting the client
+	VerifyIntent(ctx context.Context, intentID string) (*Intent, error)
+	// Refund returns money to the payer. Refunds retried with the same idempotency key are
+	// made only once, each retry returning the first refund.
+	Refund(ctx context.Context, intentID string, amount float64, idempotencyKey string) (*Refund, error)
+	// ParseWebhook checks the signature of a webhook request and decodes its event
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+}
+
+// Simulator settles intents by hand and produces the webhook the gateway would
+// send for them. Only providers meant for local development implement it.
+type Simulator interface {
+	Simulate(intentID string, status Status) (payload []byte, signature string, err error)
+}
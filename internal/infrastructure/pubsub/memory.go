@@ -0,0 +1,45 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryPubSub delivers messages within a single instance, for running one node and in tests
+type memoryPubSub struct {
+	handlers map[int]Handler
+	nextID   int
+	mu       sync.Mutex
+}
+
+func NewMemoryPubSub() PubSub {
+	return &memoryPubSub{
+		handlers: make(map[int]Handler),
+	}
+}
+
+func (p *memoryPubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	// Messages are delivered one at a time, as they are when they come from Postgres
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, handler := range p.handlers {
+		handler(channel, payload)
+	}
+	return nil
+}
+
+func (p *memoryPubSub) Subscribe(ctx context.Context, handler Handler) error {
+	p.mu.Lock()
+	id := p.nextID
+	p.nextID++
+	p.handlers[id] = handler
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		delete(p.handlers, id)
+		p.mu.Unlock()
+	}()
+	return nil
+}
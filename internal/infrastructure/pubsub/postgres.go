@@ -0,0 +1,148 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	// notifyChannel is the Postgres channel every message is sent on; the channel a
+	// message was published to travels in its payload
+	notifyChannel = "badbuddy_pubsub"
+
+	// maxNotifyPayload is the largest payload NOTIFY accepts by default
+	maxNotifyPayload = 7999
+
+	// payloadRetention is how long a stored payload is kept for listeners to load
+	payloadRetention = 5 * time.Minute
+
+	// listenerPingPeriod is how often an idle listener checks that its connection is alive
+	listenerPingPeriod = 90 * time.Second
+)
+
+// notification is the payload of a NOTIFY. A message too large for NOTIFY is stored in
+// pubsub_payloads and only its id is sent, as Ref.
+type notification struct {
+	Channel string          `json:"channel"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Ref     *uuid.UUID      `json:"ref,omitempty"`
+}
+
+// postgresPubSub fans messages out across instances with LISTEN/NOTIFY. Messages sent
+// while a listener is reconnecting are lost, as they are for a client that is offline.
+// Messages too large for a notification are stored in the database and sent by reference.
+type postgresPubSub struct {
+	db  *sqlx.DB
+	dsn string
+}
+
+// NewPostgresPubSub publishes through db and listens on a dedicated connection to dsn
+func NewPostgresPubSub(db *sqlx.DB, dsn string) PubSub {
+	return &postgresPubSub{
+		db:  db,
+		dsn: dsn,
+	}
+}
+
+func (p *postgresPubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	body, err := json.Marshal(notification{Channel: channel, Payload: payload})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	if len(body) > maxNotifyPayload {
+		if body, err = p.storePayload(ctx, channel, payload); err != nil {
+			return err
+		}
+	}
+
+	if _, err := p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, string(body)); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+	return nil
+}
+
+// storePayload saves a payload too large for NOTIFY and returns a notification referring
+// to it. Payloads every listener has had time to load are removed on the way.
+func (p *postgresPubSub) storePayload(ctx context.Context, channel string, payload []byte) ([]byte, error) {
+	_, err := p.db.ExecContext(ctx, `DELETE FROM pubsub_payloads WHERE created_at < $1`, time.Now().Add(-payloadRetention))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove expired payloads: %w", err)
+	}
+
+	id := uuid.New()
+	if _, err := p.db.ExecContext(ctx, `INSERT INTO pubsub_payloads (id, payload) VALUES ($1, $2)`, id, string(payload)); err != nil {
+		return nil, fmt.Errorf("failed to store payload: %w", err)
+	}
+
+	body, err := json.Marshal(notification{Channel: channel, Ref: &id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return body, nil
+}
+
+// loadPayload returns the payload a notification refers to
+func (p *postgresPubSub) loadPayload(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	var payload string
+	if err := p.db.GetContext(ctx, &payload, `SELECT payload FROM pubsub_payloads WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+	return []byte(payload), nil
+}
+
+func (p *postgresPubSub) Subscribe(ctx context.Context, handler Handler) error {
+	listener := pq.NewListener(p.dsn, 10*time.Millisecond, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("pubsub listener: %v", err)
+		}
+	})
+	if err := listener.Listen(notifyChannel); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	go func() {
+		defer listener.Close()
+
+		ticker := time.NewTicker(listenerPingPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-listener.Notify:
+				// A nil notification means the connection was re-established
+				if n == nil {
+					continue
+				}
+
+				var msg notification
+				if err := json.Unmarshal([]byte(n.Extra), &msg); err != nil {
+					log.Printf("pubsub: invalid notification: %v", err)
+					continue
+				}
+				if msg.Ref != nil {
+					payload, err := p.loadPayload(ctx, *msg.Ref)
+					if err != nil {
+						log.Printf("pubsub: failed to load payload %s: %v", msg.Ref, err)
+						continue
+					}
+					msg.Payload = payload
+				}
+				handler(msg.Channel, msg.Payload)
+			case <-ticker.C:
+				go listener.Ping()
+			}
+		}
+	}()
+	return nil
+}
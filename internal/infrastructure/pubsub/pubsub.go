@@ -0,0 +1,16 @@
+package pubsub
+
+import "context"
+
+// Handler receives a message published on a channel
+type Handler func(channel string, payload []byte)
+
+// PubSub fans messages out to every instance of the API. Payloads must be JSON.
+type PubSub interface {
+	// Publish sends a message to the subscribers of every instance, including this one
+	Publish(ctx context.Context, channel string, payload []byte) error
+
+	// Subscribe calls handler for every message published on any channel until ctx is
+	// done. Handlers are called from a single goroutine and should not block.
+	Subscribe(ctx context.Context, handler Handler) error
+}
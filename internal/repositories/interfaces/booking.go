@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"badbuddy/internal/domain/models"
@@ -9,21 +10,52 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrBookingConflict is returned when a court is already taken for the requested time
+var ErrBookingConflict = errors.New("booking conflict")
+
 // BookingRepository defines the interface for court booking data operations
 type BookingRepository interface {
 	Create(ctx context.Context, booking *models.CourtBooking) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.CourtBooking, error)
 	List(ctx context.Context, userID uuid.UUID, filters map[string]interface{}, limit, offset int) ([]models.CourtBooking, error)
 	Update(ctx context.Context, booking *models.CourtBooking) error
+	// ApplyPromotion stores the booking's discount and reduced total unless it already has a promotion
+	ApplyPromotion(ctx context.Context, booking *models.CourtBooking) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetUserBookings(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.CourtBooking, error)
 	GetVenueBookings(ctx context.Context, venueID uuid.UUID, startDate, endDate time.Time) ([]models.CourtBooking, error)
 	GetCourtBookings(ctx context.Context, courtID uuid.UUID, date time.Time) ([]models.CourtBooking, error)
+	// GetVenueBookedSlots returns the slots held on every court of a venue by bookings or open
+	// sessions, with only the court, date, times and status filled in
+	GetVenueBookedSlots(ctx context.Context, venueID uuid.UUID, startDate, endDate time.Time) ([]models.CourtBooking, error)
+	// GetCourtsBookedSlots returns the slots held on several courts on a date by bookings or open
+	// sessions, with only the court, date, times and status filled in
+	GetCourtsBookedSlots(ctx context.Context, courtIDs []uuid.UUID, date time.Time) ([]models.CourtBooking, error)
+	// CheckCourtAvailability reports whether no active booking overlaps the slot. Opening hours are not checked.
 	CheckCourtAvailability(ctx context.Context, courtID uuid.UUID, date time.Time, startTime, endTime time.Time) (bool, error)
 	CancelBooking(ctx context.Context, id uuid.UUID) error
+	// GetSessionBookings locks and returns the bookings made for a session that are not cancelled, without payments
+	GetSessionBookings(ctx context.Context, sessionID uuid.UUID) ([]models.CourtBooking, error)
+	// ExpireHolds cancels unpaid pending checkout bookings whose hold ended before now and returns them.
+	// Session and recurring bookings are never expired.
+	ExpireHolds(ctx context.Context, now time.Time) ([]models.CourtBooking, error)
+	AddStatusHistory(ctx context.Context, history *models.BookingStatusHistory) error
 	GetPayment(ctx context.Context, bookingID uuid.UUID) (*models.Payment, error)
+	GetPaymentsByTransactionID(ctx context.Context, transactionID string) ([]models.Payment, error)
+	GetOrderPayments(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error)
 	CreatePayment(ctx context.Context, payment *models.Payment) error
 	UpdatePayment(ctx context.Context, payment *models.Payment) error
+	CreateRefund(ctx context.Context, refund *models.Refund) error
+	// GetPendingRefunds lists the oldest refunds not yet sent to the payment gateway, with the
+	// transaction ID of their payment
+	GetPendingRefunds(ctx context.Context, limit int) ([]models.Refund, error)
+	CompleteRefund(ctx context.Context, id uuid.UUID, providerRefundID string) error
+	CreateBookingSeries(ctx context.Context, series *models.BookingSeries) error
+	GetBookingSeries(ctx context.Context, id uuid.UUID) (*models.BookingSeries, error)
+	GetSeriesBookings(ctx context.Context, seriesID uuid.UUID) ([]models.CourtBooking, error)
+	CreateBookingOrder(ctx context.Context, order *models.BookingOrder) error
+	GetBookingOrder(ctx context.Context, id uuid.UUID) (*models.BookingOrder, error)
+	GetOrderBookings(ctx context.Context, orderID uuid.UUID) ([]models.CourtBooking, error)
 	Count(ctx context.Context, userID uuid.UUID, filters map[string]interface{}) (int, error) // Added Count method
 
 }
@@ -1,8 +1,8 @@
 package interfaces
 
 import (
-	"context"
 	"badbuddy/internal/domain/models"
+	"context"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +11,8 @@ type ChatRepository interface {
 	GetChatMessageByID(ctx context.Context, chatID uuid.UUID, limit int, offset int) (*[]models.Message, error) // Get messages of a chat
 	GetChatByID(ctx context.Context, chatID uuid.UUID) (*models.Chat, error)
 	IsUserPartOfChat(ctx context.Context, userID, chatID uuid.UUID) (bool, error)
+	// SaveMessage returns the message already saved with the same chat, sender and client
+	// message ID instead of saving it twice
 	SaveMessage(ctx context.Context, message *models.Message) (*models.Message, error)
 	CreateChat(ctx context.Context, chat *models.Chat) error
 	AddUserToChat(ctx context.Context, userID, chatID uuid.UUID) error
@@ -25,4 +27,4 @@ type ChatRepository interface {
 	GetDirectChatID(ctx context.Context, userID, otherUserID uuid.UUID) (uuid.UUID, error)
 	IsUserPartOfSession(ctx context.Context, userID, sessionID uuid.UUID) (bool, error)
 	GetChatIDBySessionID(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, error)
-}
\ No newline at end of file
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicateConnection = errors.New("connection already exists")
+
+type ConnectionRepository interface {
+	// Create returns ErrDuplicateConnection if the two players are already linked in either direction
+	Create(ctx context.Context, connection *models.PlayerConnection) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PlayerConnection, error)
+	GetBetween(ctx context.Context, userID, otherID uuid.UUID) (*models.PlayerConnection, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.ConnectionStatus) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListConnections(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PlayerConnection, error)
+	CountConnections(ctx context.Context, userID uuid.UUID) (int, error)
+	ListIncomingRequests(ctx context.Context, userID uuid.UUID) ([]models.PlayerConnection, error)
+	ListOutgoingRequests(ctx context.Context, userID uuid.UUID) ([]models.PlayerConnection, error)
+	GetMutualConnections(ctx context.Context, userID, otherID uuid.UUID) ([]models.PlayerConnection, error)
+	GetPlayingPartners(ctx context.Context, userID uuid.UUID, minSessions, limit int) ([]models.PlayingPartner, error)
+}
@@ -21,4 +21,12 @@ type CourtRepository interface {
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.CourtStatus) error
 	GetAvailableCourts(ctx context.Context, venueID uuid.UUID, date time.Time, startTime, endTime time.Time) ([]models.Court, error)
 	Count(ctx context.Context, filters map[string]interface{}) (int, error)
+	// SearchCourts finds the courts of active venues that have all the facilities and, when a point
+	// is given, lie within radiusKm of it. Results carry the distance to the point.
+	SearchCourts(ctx context.Context, facilities []string, latitude, longitude *float64, radiusKm float64) ([]models.CourtSearchResult, error)
+	// LockCourts holds the court rows until the surrounding transaction ends
+	LockCourts(ctx context.Context, ids []uuid.UUID) error
+	// HasUpcomingReservations reports whether the court has an active booking or an open session
+	// reservation on or after a date
+	HasUpcomingReservations(ctx context.Context, courtID uuid.UUID, from time.Time) (bool, error)
 }
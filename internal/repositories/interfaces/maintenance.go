@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type MaintenanceRepository interface {
+	Create(ctx context.Context, window *models.MaintenanceWindow) error
+	// Delete returns sql.ErrNoRows if the court has no such maintenance window
+	Delete(ctx context.Context, courtID, id uuid.UUID) error
+	// ListUpcoming lists the windows of a venue's courts, or of one court, that end after from
+	ListUpcoming(ctx context.Context, venueID uuid.UUID, courtID *uuid.UUID, from time.Time) ([]models.MaintenanceWindow, error)
+	// GetOverlapping lists a court's windows that overlap the period from start to end
+	GetOverlapping(ctx context.Context, courtID uuid.UUID, start, end time.Time) ([]models.MaintenanceWindow, error)
+	// GetVenueOverlapping lists the windows of all of a venue's courts that overlap the period from start to end
+	GetVenueOverlapping(ctx context.Context, venueID uuid.UUID, start, end time.Time) ([]models.MaintenanceWindow, error)
+	// GetCourtsOverlapping lists the windows of several courts that overlap the period from start to end
+	GetCourtsOverlapping(ctx context.Context, courtIDs []uuid.UUID, start, end time.Time) ([]models.MaintenanceWindow, error)
+	// GetActive lists the windows of every court that are in progress at a moment
+	GetActive(ctx context.Context, at time.Time) ([]models.MaintenanceWindow, error)
+}
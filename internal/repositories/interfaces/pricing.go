@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicateHoliday = errors.New("holiday already exists for this date")
+
+type PricingRepository interface {
+	CreateRule(ctx context.Context, rule *models.PricingRule) error
+	ListRules(ctx context.Context, venueID uuid.UUID) ([]models.PricingRule, error)
+	// DeleteRule returns sql.ErrNoRows if the venue has no such rule
+	DeleteRule(ctx context.Context, venueID, id uuid.UUID) error
+	// CreateHoliday returns ErrDuplicateHoliday if the venue already has a holiday on that date
+	CreateHoliday(ctx context.Context, holiday *models.Holiday) error
+	ListHolidays(ctx context.Context, venueID uuid.UUID, from time.Time) ([]models.Holiday, error)
+	// DeleteHoliday returns sql.ErrNoRows if the venue has no such holiday
+	DeleteHoliday(ctx context.Context, venueID, id uuid.UUID) error
+	// GetVenuePricing loads the venue's rules and whether the date is one of its holidays
+	GetVenuePricing(ctx context.Context, venueID uuid.UUID, date time.Time) (*models.VenuePricing, error)
+	// GetVenuesPricing loads the pricing of several venues on a date, keyed by venue
+	GetVenuesPricing(ctx context.Context, venueIDs []uuid.UUID, date time.Time) (map[uuid.UUID]*models.VenuePricing, error)
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicatePromotionCode = errors.New("promotion code already exists")
+
+type PromotionRepository interface {
+	// Create returns ErrDuplicatePromotionCode if the code is already taken
+	Create(ctx context.Context, promotion *models.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Promotion, error)
+	// LockByCode loads a promotion by its code and locks it until the transaction ends,
+	// so concurrent redemptions of the same code are checked against its caps one at a time
+	LockByCode(ctx context.Context, code string) (*models.Promotion, error)
+	// List returns the promotions of a venue, or every promotion when venueID is nil
+	List(ctx context.Context, venueID *uuid.UUID) ([]models.Promotion, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	CountUserRedemptions(ctx context.Context, promotionID, userID uuid.UUID) (int, error)
+	// Redeem records the redemption and counts it against the promotion's total uses
+	Redeem(ctx context.Context, redemption *models.PromotionRedemption) error
+	// ReleaseRedemption gives back the use of a promotion by a booking that was never paid
+	ReleaseRedemption(ctx context.Context, bookingID uuid.UUID) error
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicateReview = errors.New("player already reviewed for this session")
+
+type PlayerReviewRepository interface {
+	// Create returns ErrDuplicateReview if the reviewer already rated the player for the session
+	Create(ctx context.Context, review *models.PlayerReview) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PlayerReview, error)
+	Exists(ctx context.Context, reviewerID, reviewedID, sessionID uuid.UUID) (bool, error)
+	GetReceived(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PlayerReview, error)
+	GetGiven(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PlayerReview, error)
+	CountGiven(ctx context.Context, userID uuid.UUID) (int, error)
+	GetRatingSummary(ctx context.Context, userID uuid.UUID) (*models.PlayerRatingSummary, error)
+	GetReviewedIDs(ctx context.Context, reviewerID, sessionID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicateScheduleOverride = errors.New("opening hours are already overridden for this date")
+
+type ScheduleRepository interface {
+	// CreateOverride returns ErrDuplicateScheduleOverride if the venue or court already has special hours on that date
+	CreateOverride(ctx context.Context, override *models.ScheduleOverride) error
+	GetOverride(ctx context.Context, venueID, id uuid.UUID) (*models.ScheduleOverride, error)
+	ListOverrides(ctx context.Context, venueID uuid.UUID, from time.Time) ([]models.ScheduleOverride, error)
+	// DeleteOverride returns sql.ErrNoRows if the venue has no such override
+	DeleteOverride(ctx context.Context, venueID, id uuid.UUID) error
+	// GetDateOverrides returns every override of the venue and its courts on a date
+	GetDateOverrides(ctx context.Context, venueID uuid.UUID, date time.Time) ([]models.ScheduleOverride, error)
+	// GetRangeOverrides returns every override of the venue and its courts from one date to another, inclusive
+	GetRangeOverrides(ctx context.Context, venueID uuid.UUID, from, to time.Time) ([]models.ScheduleOverride, error)
+	// GetVenuesDateOverrides returns every override of several venues and their courts on a date
+	GetVenuesDateOverrides(ctx context.Context, venueIDs []uuid.UUID, date time.Time) ([]models.ScheduleOverride, error)
+}
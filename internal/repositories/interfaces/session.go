@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"badbuddy/internal/domain/models"
 
@@ -10,6 +11,8 @@ import (
 
 type SessionRepository interface {
 	Create(ctx context.Context, session *models.Session) error
+	// Lock holds the session row until the surrounding transaction ends
+	Lock(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.SessionDetail, error)
 	Update(ctx context.Context, session *models.Session) error
 	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error)
@@ -21,4 +24,13 @@ type SessionRepository interface {
 	GetMyJoinedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error)
 	GetMyHostedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error)
 	GetJoinStatus(ctx context.Context, userID, venueID uuid.UUID) (models.JoinStatus, error)
+	AddSessionCourt(ctx context.Context, sessionCourt *models.SessionCourt) error
+	GetSessionCourts(ctx context.Context, sessionID uuid.UUID) ([]models.SessionCourt, error)
+	ReleaseSessionCourts(ctx context.Context, sessionID uuid.UUID) error
+	RemoveSessionCourt(ctx context.Context, sessionID, courtID uuid.UUID) error
+	GetCourtSessions(ctx context.Context, courtID uuid.UUID, date time.Time) ([]models.Session, error)
+	// GetCourtSessionsBetween lists the open sessions holding a court on the dates from first to last
+	GetCourtSessionsBetween(ctx context.Context, courtID uuid.UUID, first, last time.Time) ([]models.Session, error)
+	CreateSeries(ctx context.Context, series *models.SessionSeries) error
+	GetSeriesSessions(ctx context.Context, seriesID uuid.UUID, from time.Time) ([]models.Session, error)
 }
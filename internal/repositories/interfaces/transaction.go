@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+// TransactionManager runs several repository calls as a single unit of work.
+// Repositories called with the context handed to fn take part in the transaction.
+type TransactionManager interface {
+	// WithinTransaction commits if fn returns nil and rolls back otherwise.
+	// Nested calls join the transaction already carried by ctx.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
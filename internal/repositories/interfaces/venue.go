@@ -12,9 +12,10 @@ type VenueRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.VenueWithCourts, error)
 	Update(ctx context.Context, venue *models.Venue) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, location string, limit, offset int) ([]models.Venue, error)
+	// List and Search order venues by distance when the geo query has a point
+	List(ctx context.Context, location string, geo *models.GeoQuery, limit, offset int) ([]models.Venue, error)
 	CountVenues(ctx context.Context) (int, error)
-	Search(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facility []string) ([]models.Venue, error)
+	Search(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facility []string, geo *models.GeoQuery) ([]models.Venue, error)
 	AddCourt(ctx context.Context, court *models.Court) error
 	UpdateCourt(ctx context.Context, court *models.Court) error
 	DeleteCourt(ctx context.Context, id uuid.UUID) error
@@ -25,5 +26,5 @@ type VenueRepository interface {
 	GetFacilities(ctx context.Context, venueID uuid.UUID) ([]models.Facility, error)
 	AddFacilities(ctx context.Context, venueID uuid.UUID, facilityIDs []uuid.UUID) error
 	UpdateFacilities(ctx context.Context, venueID uuid.UUID, facilityIDs []uuid.UUID) error
-	CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string) (int, error)
+	CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string, geo *models.GeoQuery) (int, error)
 }
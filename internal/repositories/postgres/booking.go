@@ -2,17 +2,15 @@ package postgres
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
-	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
 	"badbuddy/internal/repositories/interfaces"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type bookingRepository struct {
@@ -36,20 +34,28 @@ func (r *bookingRepository) Create(ctx context.Context, booking *models.CourtBoo
 		return fmt.Errorf("error checking availability: %w", err)
 	}
 	if !isAvailable {
-		return fmt.Errorf("court is not available for the requested time")
+		return fmt.Errorf("%w: court is not available for the requested time", interfaces.ErrBookingConflict)
 	}
 
 	query := `
         INSERT INTO court_bookings (
-            id, court_id, user_id, booking_date, start_time, end_time,
-            total_amount, status, notes, created_at, updated_at
+            id, court_id, user_id, session_id, series_id, order_id, booking_date, start_time, end_time,
+            total_amount, promotion_id, discount_amount, status, notes, hold_expires_at, created_at, updated_at
         ) VALUES (
-            :id, :court_id, :user_id, :booking_date, :start_time, :end_time,
-            :total_amount, :status, :notes, :created_at, :updated_at
+            :id, :court_id, :user_id, :session_id, :series_id, :order_id, :booking_date, :start_time, :end_time,
+            :total_amount, :promotion_id, :discount_amount, :status, :notes, :hold_expires_at, :created_at, :updated_at
         )`
 
-	_, err = r.db.NamedExecContext(ctx, query, booking)
-	return err
+	_, err = conn(ctx, r.db).NamedExecContext(ctx, query, booking)
+	if err != nil {
+		// A concurrent booking won the slot between the check above and the insert
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23P01" {
+			return fmt.Errorf("%w: court is not available for the requested time", interfaces.ErrBookingConflict)
+		}
+		return err
+	}
+
+	return nil
 }
 func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CourtBooking, error) {
 	query := `
@@ -67,7 +73,7 @@ func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		WHERE b.id = $1`
 
 	var booking models.CourtBooking
-	err := r.db.GetContext(ctx, &booking, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, &booking, query, id)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +81,7 @@ func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	// Get associated payment if exists
 	paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
 	var payment models.Payment
-	if err := r.db.GetContext(ctx, &payment, paymentQuery, id); err == nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &payment, paymentQuery, id); err == nil {
 		booking.Payment = &payment
 	}
 
@@ -144,7 +150,7 @@ func (r *bookingRepository) List(ctx context.Context, userID uuid.UUID, filters
 	}
 
 	var bookings []models.CourtBooking
-	err := r.db.SelectContext(ctx, &bookings, query, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +159,7 @@ func (r *bookingRepository) List(ctx context.Context, userID uuid.UUID, filters
 	for i, booking := range bookings {
 		var payment models.Payment
 		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
-		if err := r.db.GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
+		if err := conn(ctx, r.db).GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
 			bookings[i].Payment = &payment
 		}
 	}
@@ -169,7 +175,7 @@ func (r *bookingRepository) Update(ctx context.Context, booking *models.CourtBoo
 			updated_at = :updated_at
 		WHERE id = :id`
 
-	result, err := r.db.NamedExecContext(ctx, query, booking)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, booking)
 	if err != nil {
 		return err
 	}
@@ -186,9 +192,35 @@ func (r *bookingRepository) Update(ctx context.Context, booking *models.CourtBoo
 	return nil
 }
 
+func (r *bookingRepository) ApplyPromotion(ctx context.Context, booking *models.CourtBooking) error {
+	query := `
+		UPDATE court_bookings SET
+			promotion_id = :promotion_id,
+			discount_amount = :discount_amount,
+			total_amount = :total_amount,
+			updated_at = :updated_at
+		WHERE id = :id AND promotion_id IS NULL`
+
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, booking)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("a promotion has already been applied to this booking")
+	}
+
+	return nil
+}
+
 func (r *bookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM court_bookings WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -227,7 +259,7 @@ func (r *bookingRepository) GetUserBookings(ctx context.Context, userID uuid.UUI
 	query += " ORDER BY b.booking_date ASC, b.start_time ASC"
 
 	var bookings []models.CourtBooking
-	err := r.db.SelectContext(ctx, &bookings, query, userID)
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +268,7 @@ func (r *bookingRepository) GetUserBookings(ctx context.Context, userID uuid.UUI
 	for i, booking := range bookings {
 		var payment models.Payment
 		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
-		if err := r.db.GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
+		if err := conn(ctx, r.db).GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
 			bookings[i].Payment = &payment
 		}
 	}
@@ -261,7 +293,7 @@ func (r *bookingRepository) GetVenueBookings(ctx context.Context, venueID uuid.U
 		ORDER BY b.booking_date ASC, b.start_time ASC`
 
 	var bookings []models.CourtBooking
-	err := r.db.SelectContext(ctx, &bookings, query, venueID, startDate, endDate)
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, venueID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
@@ -270,7 +302,7 @@ func (r *bookingRepository) GetVenueBookings(ctx context.Context, venueID uuid.U
 	for i, booking := range bookings {
 		var payment models.Payment
 		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
-		if err := r.db.GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
+		if err := conn(ctx, r.db).GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
 			bookings[i].Payment = &payment
 		}
 	}
@@ -295,12 +327,57 @@ func (r *bookingRepository) GetCourtBookings(ctx context.Context, courtID uuid.U
 		ORDER BY b.start_time ASC`
 
 	var bookings []models.CourtBooking
-	err := r.db.SelectContext(ctx, &bookings, query, courtID, date)
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, courtID, date)
+	return bookings, err
+}
+
+func (r *bookingRepository) GetVenueBookedSlots(ctx context.Context, venueID uuid.UUID, startDate, endDate time.Time) ([]models.CourtBooking, error) {
+	// Courts reserved by a session without a booking of their own hold their slot as well
+	query := `
+		SELECT b.court_id, b.booking_date, b.start_time, b.end_time, b.status
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		WHERE c.venue_id = $1
+		AND b.booking_date BETWEEN $2::date AND $3::date
+		AND b.status != 'cancelled'
+		UNION ALL
+		SELECT sc.court_id, ps.session_date, ps.start_time, ps.end_time, 'confirmed'
+		FROM session_courts sc
+		JOIN play_sessions ps ON ps.id = sc.session_id
+		JOIN courts c ON c.id = sc.court_id
+		WHERE c.venue_id = $1
+		AND ps.session_date BETWEEN $2::date AND $3::date
+		AND ps.status NOT IN ('cancelled', 'completed')
+		ORDER BY booking_date ASC, start_time ASC`
+
+	bookings := []models.CourtBooking{}
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, venueID, startDate, endDate)
+	return bookings, err
+}
+
+func (r *bookingRepository) GetCourtsBookedSlots(ctx context.Context, courtIDs []uuid.UUID, date time.Time) ([]models.CourtBooking, error) {
+	// Courts reserved by a session without a booking of their own hold their slot as well
+	query := `
+		SELECT court_id, booking_date, start_time, end_time, status
+		FROM court_bookings
+		WHERE court_id = ANY($1::uuid[])
+		AND booking_date = $2::date
+		AND status != 'cancelled'
+		UNION ALL
+		SELECT sc.court_id, ps.session_date, ps.start_time, ps.end_time, 'confirmed'
+		FROM session_courts sc
+		JOIN play_sessions ps ON ps.id = sc.session_id
+		WHERE sc.court_id = ANY($1::uuid[])
+		AND ps.session_date = $2::date
+		AND ps.status NOT IN ('cancelled', 'completed')
+		ORDER BY start_time ASC`
+
+	bookings := []models.CourtBooking{}
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, models.UUIDList(courtIDs), date)
 	return bookings, err
 }
 
 func (r *bookingRepository) CheckCourtAvailability(ctx context.Context, courtID uuid.UUID, date time.Time, startTime, endTime time.Time) (bool, error) {
-	// First check if any existing bookings conflict
 	bookingQuery := `
         SELECT COUNT(*)
         FROM court_bookings
@@ -314,60 +391,12 @@ func (r *bookingRepository) CheckCourtAvailability(ctx context.Context, courtID
         )`
 
 	var bookingCount int
-	if err := r.db.GetContext(ctx, &bookingCount, bookingQuery, courtID, date, startTime, endTime); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &bookingCount, bookingQuery, courtID, date, startTime, endTime); err != nil {
 		return false, err
 	}
 
-	if bookingCount > 0 {
-		return false, nil
-	}
-
-	// Then check if the venue is open during the requested time
-	venueQuery := `
-        SELECT v.open_range
-        FROM courts c
-        JOIN venues v ON v.id = c.venue_id
-        WHERE c.id = $1`
-	var openRangeJson json.RawMessage
-	if err := r.db.GetContext(ctx, &openRangeJson, venueQuery, courtID); err != nil {
-		return false, err
-	}
-	var openRange []responses.OpenRangeResponse
-	errParseOpenRangeJson := json.Unmarshal(openRangeJson, &openRange)
-	if errParseOpenRangeJson != nil {
-		return false, errParseOpenRangeJson
-	}
-	// Get the day of week for the booking date
-	dayOfWeek := strings.ToLower(date.Weekday().String())
-
-	// Check if the requested time falls within venue operating hours
-	for _, schedule := range openRange {
-		if strings.ToLower(schedule.Day) == dayOfWeek {
-			// Convert schedule times to same date as booking for comparison
-			scheduleOpen := time.Date(
-				startTime.Year(), startTime.Month(), startTime.Day(),
-				schedule.OpenTime.Hour(), schedule.OpenTime.Minute(), 0, 0,
-				startTime.Location(),
-			)
-			scheduleClose := time.Date(
-				startTime.Year(), startTime.Month(), startTime.Day(),
-				schedule.CloseTime.Hour(), schedule.CloseTime.Minute(), 0, 0,
-				startTime.Location(),
-			)
-
-			// Check if booking time falls within operating hours
-			if startTime.Hour() < scheduleOpen.Hour() ||
-				(startTime.Hour() == scheduleOpen.Hour() && startTime.Minute() < scheduleOpen.Minute()) ||
-				endTime.Hour() > scheduleClose.Hour() ||
-				(endTime.Hour() == scheduleClose.Hour() && endTime.Minute() > scheduleClose.Minute()) {
-				return false, nil
-			}
-			return true, nil
-		}
-	}
-
-	// If we didn't find the day in the schedule, venue is closed
-	return false, nil
+	// Opening hours are checked by the use case, which knows about schedule overrides
+	return bookingCount == 0, nil
 }
 
 func (r *bookingRepository) CancelBooking(ctx context.Context, id uuid.UUID) error {
@@ -378,7 +407,7 @@ func (r *bookingRepository) CancelBooking(ctx context.Context, id uuid.UUID) err
 			updated_at = NOW()
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -395,11 +424,65 @@ func (r *bookingRepository) CancelBooking(ctx context.Context, id uuid.UUID) err
 	return nil
 }
 
+func (r *bookingRepository) GetSessionBookings(ctx context.Context, sessionID uuid.UUID) ([]models.CourtBooking, error) {
+	query := `
+		SELECT *
+		FROM court_bookings
+		WHERE session_id = $1 AND status != 'cancelled'
+		ORDER BY booking_date ASC, start_time ASC
+		FOR UPDATE`
+
+	bookings := []models.CourtBooking{}
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, sessionID)
+	return bookings, err
+}
+
+func (r *bookingRepository) ExpireHolds(ctx context.Context, now time.Time) ([]models.CourtBooking, error) {
+	query := `
+		WITH expired AS (
+			UPDATE court_bookings b
+			SET status = 'cancelled',
+				cancelled_at = $1,
+				updated_at = $1
+			WHERE b.status = 'pending'
+			AND b.hold_expires_at <= $1
+			-- Session and recurring bookings are reserved for their season, not held for checkout
+			AND b.session_id IS NULL
+			AND b.series_id IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM payments p
+				WHERE p.booking_id = b.id AND p.status = 'completed'
+			)
+			RETURNING b.*
+		), history AS (
+			INSERT INTO booking_status_history (booking_id, from_status, to_status, reason, created_at)
+			SELECT id, 'pending', 'cancelled', $2, $1
+			FROM expired
+		)
+		SELECT * FROM expired`
+
+	bookings := []models.CourtBooking{}
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, now, models.BookingReasonHoldExpired)
+	return bookings, err
+}
+
+func (r *bookingRepository) AddStatusHistory(ctx context.Context, history *models.BookingStatusHistory) error {
+	query := `
+		INSERT INTO booking_status_history (
+			id, booking_id, from_status, to_status, reason, changed_by, created_at
+		) VALUES (
+			:id, :booking_id, :from_status, :to_status, :reason, :changed_by, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, history)
+	return err
+}
+
 func (r *bookingRepository) GetPayment(ctx context.Context, bookingID uuid.UUID) (*models.Payment, error) {
 	query := `SELECT * FROM payments WHERE booking_id = $1`
 
 	var payment models.Payment
-	err := r.db.GetContext(ctx, &payment, query, bookingID)
+	err := conn(ctx, r.db).GetContext(ctx, &payment, query, bookingID)
 	if err != nil {
 		return nil, err
 	}
@@ -407,17 +490,37 @@ func (r *bookingRepository) GetPayment(ctx context.Context, bookingID uuid.UUID)
 	return &payment, nil
 }
 
+// GetPaymentsByTransactionID locks the payments a gateway reported on so concurrent
+// deliveries of the same webhook are applied once. The bookings of an order share
+// one transaction, so there can be several.
+func (r *bookingRepository) GetPaymentsByTransactionID(ctx context.Context, transactionID string) ([]models.Payment, error) {
+	query := `SELECT * FROM payments WHERE transaction_id = $1 ORDER BY id FOR UPDATE`
+
+	payments := []models.Payment{}
+	err := conn(ctx, r.db).SelectContext(ctx, &payments, query, transactionID)
+	return payments, err
+}
+
+// GetOrderPayments locks the payments of every booking in an order
+func (r *bookingRepository) GetOrderPayments(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error) {
+	query := `SELECT * FROM payments WHERE order_id = $1 ORDER BY id FOR UPDATE`
+
+	payments := []models.Payment{}
+	err := conn(ctx, r.db).SelectContext(ctx, &payments, query, orderID)
+	return payments, err
+}
+
 func (r *bookingRepository) CreatePayment(ctx context.Context, payment *models.Payment) error {
 	query := `
 		INSERT INTO payments (
-			id, booking_id, user_id, amount, status, payment_method,
+			id, booking_id, order_id, user_id, amount, status, payment_method,
 			transaction_id, created_at, updated_at
 		) VALUES (
-			:id, :booking_id, :user_id, :amount, :status, :payment_method,
+			:id, :booking_id, :order_id, :user_id, :amount, :status, :payment_method,
 			:transaction_id, :created_at, :updated_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, payment)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, payment)
 	return err
 }
 
@@ -426,10 +529,11 @@ func (r *bookingRepository) UpdatePayment(ctx context.Context, payment *models.P
 		UPDATE payments SET
 			status = :status,
 			payment_method = :payment_method,
+			transaction_id = :transaction_id,
 			updated_at = :updated_at
 		WHERE id = :id`
 
-	result, err := r.db.NamedExecContext(ctx, query, payment)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, payment)
 	if err != nil {
 		return err
 	}
@@ -446,6 +550,135 @@ func (r *bookingRepository) UpdatePayment(ctx context.Context, payment *models.P
 	return nil
 }
 
+func (r *bookingRepository) CreateRefund(ctx context.Context, refund *models.Refund) error {
+	query := `
+		INSERT INTO refunds (
+			id, booking_id, payment_id, amount, refund_percent, reason,
+			provider_refund_id, status, created_at
+		) VALUES (
+			:id, :booking_id, :payment_id, :amount, :refund_percent, :reason,
+			:provider_refund_id, :status, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, refund)
+	return err
+}
+
+func (r *bookingRepository) GetPendingRefunds(ctx context.Context, limit int) ([]models.Refund, error) {
+	query := `
+		SELECT r.*, p.transaction_id
+		FROM refunds r
+		JOIN payments p ON p.id = r.payment_id
+		WHERE r.status = 'pending'
+		ORDER BY r.created_at
+		LIMIT $1`
+
+	refunds := []models.Refund{}
+	err := conn(ctx, r.db).SelectContext(ctx, &refunds, query, limit)
+	return refunds, err
+}
+
+func (r *bookingRepository) CompleteRefund(ctx context.Context, id uuid.UUID, providerRefundID string) error {
+	query := `
+		UPDATE refunds
+		SET status = 'completed', provider_refund_id = $2
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, providerRefundID)
+	return err
+}
+
+func (r *bookingRepository) CreateBookingSeries(ctx context.Context, series *models.BookingSeries) error {
+	query := `
+		INSERT INTO booking_series (
+			id, user_id, court_id, weekdays, starts_on, ends_on,
+			start_time, end_time, notes, created_at
+		) VALUES (
+			:id, :user_id, :court_id, :weekdays, :starts_on, :ends_on,
+			:start_time, :end_time, :notes, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, series)
+	return err
+}
+
+func (r *bookingRepository) GetBookingSeries(ctx context.Context, id uuid.UUID) (*models.BookingSeries, error) {
+	query := `SELECT * FROM booking_series WHERE id = $1`
+
+	var series models.BookingSeries
+	if err := conn(ctx, r.db).GetContext(ctx, &series, query, id); err != nil {
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+// GetSeriesBookings lists the bookings of a series that are not cancelled yet
+func (r *bookingRepository) GetSeriesBookings(ctx context.Context, seriesID uuid.UUID) ([]models.CourtBooking, error) {
+	query := `
+		SELECT *
+		FROM court_bookings
+		WHERE series_id = $1 AND status != 'cancelled'
+		ORDER BY booking_date ASC, start_time ASC`
+
+	bookings := []models.CourtBooking{}
+	err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, seriesID)
+	return bookings, err
+}
+
+func (r *bookingRepository) CreateBookingOrder(ctx context.Context, order *models.BookingOrder) error {
+	query := `
+		INSERT INTO booking_orders (id, user_id, total_amount, created_at)
+		VALUES (:id, :user_id, :total_amount, :created_at)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, order)
+	return err
+}
+
+func (r *bookingRepository) GetBookingOrder(ctx context.Context, id uuid.UUID) (*models.BookingOrder, error) {
+	query := `SELECT * FROM booking_orders WHERE id = $1`
+
+	var order models.BookingOrder
+	if err := conn(ctx, r.db).GetContext(ctx, &order, query, id); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *bookingRepository) GetOrderBookings(ctx context.Context, orderID uuid.UUID) ([]models.CourtBooking, error) {
+	query := `
+		SELECT
+			b.*,
+			c.name as court_name,
+			c.price_per_hour,
+			v.name as venue_name,
+			v.location as venue_location,
+			u.first_name || ' ' || u.last_name as user_name
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		JOIN venues v ON v.id = c.venue_id
+		JOIN users u ON u.id = b.user_id
+		WHERE b.order_id = $1
+		ORDER BY b.booking_date ASC, b.start_time ASC`
+
+	bookings := []models.CourtBooking{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &bookings, query, orderID); err != nil {
+		return nil, err
+	}
+
+	// Get payments for bookings
+	for i, booking := range bookings {
+		var payment models.Payment
+		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
+		if err := conn(ctx, r.db).GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
+			bookings[i].Payment = &payment
+		}
+	}
+
+	return bookings, nil
+}
+
 func (r *bookingRepository) Count(ctx context.Context, userID uuid.UUID, filters map[string]interface{}) (int, error) {
 	query := `
 		SELECT
@@ -490,7 +723,7 @@ func (r *bookingRepository) Count(ctx context.Context, userID uuid.UUID, filters
 		argCount++
 	}
 	var count int
-	err := r.db.GetContext(ctx, &count, query, args...)
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,145 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// These tests run against a migrated database:
+//
+//	TEST_DATABASE_URL=postgres://... go test -tags integration ./internal/repositories/postgres/
+
+func openTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// createTestCourt inserts a venue, a court and a user to book it, and removes them and
+// their bookings when the test ends
+func createTestCourt(t *testing.T, db *sqlx.DB) (courtID, userID uuid.UUID) {
+	t.Helper()
+
+	venueID := uuid.New()
+	courtID = uuid.New()
+	userID = uuid.New()
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{
+			`INSERT INTO venues (id, name, address, location, status) VALUES ($1, $2, 'Test address', 'Test location', 'active')`,
+			[]interface{}{venueID, "Test venue " + venueID.String()},
+		},
+		{
+			`INSERT INTO courts (id, venue_id, name, price_per_hour, status) VALUES ($1, $2, 'Court 1', 200, 'available')`,
+			[]interface{}{courtID, venueID},
+		},
+		{
+			`INSERT INTO users (id, email, password, first_name, last_name, play_level) VALUES ($1, $2, 'x', 'Test', 'Player', 'beginner')`,
+			[]interface{}{userID, userID.String() + "@example.com"},
+		},
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("failed to create test data: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM court_bookings WHERE court_id = $1`, courtID)
+		db.Exec(`DELETE FROM users WHERE id = $1`, userID)
+		db.Exec(`DELETE FROM courts WHERE id = $1`, courtID)
+		db.Exec(`DELETE FROM venues WHERE id = $1`, venueID)
+	})
+	return courtID, userID
+}
+
+func newTestBooking(courtID, userID uuid.UUID, date time.Time, start, end string) *models.CourtBooking {
+	startTime, _ := time.Parse("15:04", start)
+	endTime, _ := time.Parse("15:04", end)
+	now := time.Now()
+	return &models.CourtBooking{
+		ID:          uuid.New(),
+		CourtID:     courtID,
+		UserID:      userID,
+		Date:        date,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		TotalAmount: 400,
+		Status:      models.BookingStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func TestBookingCreateConcurrentOverlap(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewBookingRepository(db)
+	courtID, userID := createTestCourt(t, db)
+
+	date := time.Now().AddDate(0, 0, 7).UTC().Truncate(24 * time.Hour)
+	bookings := []*models.CourtBooking{
+		newTestBooking(courtID, userID, date, "10:00", "12:00"),
+		newTestBooking(courtID, userID, date, "11:00", "13:00"),
+	}
+
+	// Release both inserts at once so they race past the availability pre-check
+	start := make(chan struct{})
+	errs := make([]error, len(bookings))
+	var wg sync.WaitGroup
+	for i, booking := range bookings {
+		wg.Add(1)
+		go func(i int, booking *models.CourtBooking) {
+			defer wg.Done()
+			<-start
+			errs[i] = repo.Create(context.Background(), booking)
+		}(i, booking)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, interfaces.ErrBookingConflict):
+			t.Fatalf("expected ErrBookingConflict, got %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("expected exactly one booking to succeed, got %d (errors: %v)", succeeded, errs)
+	}
+
+	var active int
+	if err := db.Get(&active, `SELECT COUNT(*) FROM court_bookings WHERE court_id = $1 AND status <> 'cancelled'`, courtID); err != nil {
+		t.Fatalf("failed to count bookings: %v", err)
+	}
+	if active != 1 {
+		t.Fatalf("expected 1 active booking, got %d", active)
+	}
+}
@@ -24,7 +24,7 @@ func (r *chatRepository) GetChatMessageByID(ctx context.Context, chatID uuid.UUI
 
 	query := `SELECT * FROM chats WHERE id = $1`
 
-	err := r.db.GetContext(ctx, &chat, query, chatID)
+	err := conn(ctx, r.db).GetContext(ctx, &chat, query, chatID)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (r *chatRepository) GetChatMessageByID(ctx context.Context, chatID uuid.UUI
 
 	// Get messages
 	messages := []models.Message{}
-	err = r.db.SelectContext(ctx, &messages, query, chatID, limit, offset)
+	err = conn(ctx, r.db).SelectContext(ctx, &messages, query, chatID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +76,7 @@ func (r *chatRepository) GetChatByID(ctx context.Context, chatID uuid.UUID) (*mo
 
 	query := `SELECT * FROM chats WHERE id = $1`
 
-	err := r.db.GetContext(ctx, &chat, query, chatID)
+	err := conn(ctx, r.db).GetContext(ctx, &chat, query, chatID)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +89,7 @@ func (r *chatRepository) IsUserPartOfChat(ctx context.Context, userID, chatID uu
 
 	query := `SELECT COUNT(*) FROM chat_participants WHERE user_id = $1 AND chat_id = $2`
 
-	err := r.db.GetContext(ctx, &count, query, userID, chatID)
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, userID, chatID)
 	if err != nil {
 		return false, err
 	}
@@ -99,14 +99,30 @@ func (r *chatRepository) IsUserPartOfChat(ctx context.Context, userID, chatID uu
 
 func (r *chatRepository) SaveMessage(ctx context.Context, message *models.Message) (*models.Message, error) {
 
-	query := `INSERT INTO chat_messages (id, chat_id, sender_id, type, content, created_at, updated_at, status) VALUES ($1, $2, $3, $4, $5, NOW(), NOW(), $6)`
+	query := `
+		INSERT INTO chat_messages (id, chat_id, sender_id, type, content, created_at, updated_at, status, client_message_id)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW(), $6, $7)
+		ON CONFLICT (chat_id, sender_id, client_message_id) DO NOTHING`
 
-	_, err := r.db.ExecContext(ctx, query, message.ID, message.ChatID, message.SenderID, message.Type, message.Content, message.Status)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, message.ID, message.ChatID, message.SenderID, message.Type, message.Content, message.Status, message.ClientMessageID)
+	if err != nil {
+		return nil, err
+	}
+	inserted, err := result.RowsAffected()
 	if err != nil {
 		return nil, err
 	}
 
-	messageReturn, err := r.GetMessageByID(ctx, message.ID)
+	messageID := message.ID
+	if inserted == 0 {
+		// The message was resent; return the one saved the first time
+		query = `SELECT id FROM chat_messages WHERE chat_id = $1 AND sender_id = $2 AND client_message_id = $3`
+		if err := conn(ctx, r.db).GetContext(ctx, &messageID, query, message.ChatID, message.SenderID, message.ClientMessageID); err != nil {
+			return nil, err
+		}
+	}
+
+	messageReturn, err := r.GetMessageByID(ctx, messageID)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +143,8 @@ func (r *chatRepository) GetMessageByID(ctx context.Context, messageID uuid.UUID
 			m.content,
 			m.created_at,
 			m.updated_at,
+			m.delete_at,
+			m.client_message_id,
 			u.email,
 			u.first_name,
 			u.last_name,
@@ -145,7 +163,7 @@ func (r *chatRepository) GetMessageByID(ctx context.Context, messageID uuid.UUID
 		WHERE 
 			m.id = $1`
 
-	err := r.db.GetContext(ctx, &message, query, messageID)
+	err := conn(ctx, r.db).GetContext(ctx, &message, query, messageID)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +176,7 @@ func (r *chatRepository) CreateChat(ctx context.Context, chat *models.Chat) erro
 
 	query := `INSERT INTO chats (id, type, session_id) VALUES ($1, $2, $3)`
 
-	_, err := r.db.ExecContext(ctx, query, chat.ID, chat.Type, chat.SessionID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, chat.ID, chat.Type, chat.SessionID)
 	if err != nil {
 		return err
 	}
@@ -170,7 +188,7 @@ func (r *chatRepository) AddUserToChat(ctx context.Context, userID, chatID uuid.
 
 	query := `INSERT INTO chat_participants (id, chat_id, user_id) VALUES ($1, $2, $3)`
 
-	_, err := r.db.ExecContext(ctx, query, uuid.New(), chatID, userID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, uuid.New(), chatID, userID)
 	if err != nil {
 		return err
 	}
@@ -182,7 +200,7 @@ func (r *chatRepository) RemoveUserFromChat(ctx context.Context, userID, chatID
 
 	query := `DELETE FROM chat_participants WHERE chat_id = $1 AND user_id = $2`
 
-	_, err := r.db.ExecContext(ctx, query, chatID, userID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, chatID, userID)
 	if err != nil {
 		return err
 	}
@@ -194,7 +212,7 @@ func (r *chatRepository) UpdateChatMessage(ctx context.Context, message *models.
 
 	query := `UPDATE chat_messages SET content = $1, updated_at = NOW() WHERE id = $2`
 
-	_, err := r.db.ExecContext(ctx, query, message.Content, message.ID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, message.Content, message.ID)
 	if err != nil {
 		return err
 	}
@@ -206,7 +224,7 @@ func (r *chatRepository) DeleteChatMessage(ctx context.Context, messageID uuid.U
 
 	query := `UPDATE chat_messages SET delete_at = NOW(), updated_at = NOW() WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, messageID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, messageID)
 	if err != nil {
 		return err
 	}
@@ -218,7 +236,7 @@ func (r *chatRepository) UpdateChatMessageReadStatus(ctx context.Context, chatID
 
 	query := `UPDATE chat_messages SET status = 'read' WHERE chat_id = $1 AND sender_id != $2 AND status = 'sent'`
 
-	_, err := r.db.ExecContext(ctx, query, chatID, userID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, chatID, userID)
 	if err != nil {
 		return err
 	}
@@ -231,7 +249,7 @@ func (r *chatRepository) IsUserIsSender(ctx context.Context, userID, messageID u
 
 	query := `SELECT COUNT(*) FROM chat_messages WHERE sender_id = $1 AND id = $2`
 
-	err := r.db.GetContext(ctx, &count, query, userID, messageID)
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, userID, messageID)
 	if err != nil {
 		return false, err
 	}
@@ -252,7 +270,7 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 		WHERE
 			id IN (SELECT chat_id FROM chat_participants WHERE user_id = $1)`
 
-	err := r.db.SelectContext(ctx, &chats, query, userID)
+	err := conn(ctx, r.db).SelectContext(ctx, &chats, query, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +308,7 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 				m.created_at DESC
 			LIMIT 1`
 
-		err = r.db.SelectContext(ctx, &lastMessages, query, chat.ID)
+		err = conn(ctx, r.db).SelectContext(ctx, &lastMessages, query, chat.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -327,7 +345,7 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 			WHERE
 				cp.chat_id = $1`
 
-		err = r.db.SelectContext(ctx, &chatUsers, query, chat.ID)
+		err = conn(ctx, r.db).SelectContext(ctx, &chatUsers, query, chat.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -360,7 +378,7 @@ func (r *chatRepository) GetUsersInChat(ctx context.Context, chatID uuid.UUID) (
 		WHERE
 			cp.chat_id = $1`
 
-	err := r.db.SelectContext(ctx, &users, query, chatID)
+	err := conn(ctx, r.db).SelectContext(ctx, &users, query, chatID)
 	if err != nil {
 		return nil, err
 	}
@@ -380,19 +398,19 @@ func (r *chatRepository) GetDirectChatID(ctx context.Context, userID, otherUserU
 			user_id = $1
 			AND chat_id IN (SELECT chat_id FROM chat_participants WHERE user_id = $2)`
 
-	err := r.db.GetContext(ctx, &chatID, query, userID, otherUserUUID)
+	err := conn(ctx, r.db).GetContext(ctx, &chatID, query, userID, otherUserUUID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			if chatID == uuid.Nil {
 				chatID = uuid.New()
 				query = `INSERT INTO chats (id, type) VALUES ($1, 'direct')`
-				_, err = r.db.ExecContext(ctx, query, chatID)
+				_, err = conn(ctx, r.db).ExecContext(ctx, query, chatID)
 				if err != nil {
 					return uuid.Nil, err
 				}
 
 				query = `INSERT INTO chat_participants (id, chat_id, user_id) VALUES ($1, $2, $3), ($4, $2, $5)`
-				_, err = r.db.ExecContext(ctx, query, uuid.New(), chatID, userID, uuid.New(), otherUserUUID)
+				_, err = conn(ctx, r.db).ExecContext(ctx, query, uuid.New(), chatID, userID, uuid.New(), otherUserUUID)
 				if err != nil {
 					return uuid.Nil, err
 				}
@@ -410,7 +428,7 @@ func (r *chatRepository) GetChatIDBySessionID(ctx context.Context, sessionID uui
 
 	query := `SELECT id FROM chats WHERE session_id = $1`
 
-	err := r.db.GetContext(ctx, &chatID, query, sessionID)
+	err := conn(ctx, r.db).GetContext(ctx, &chatID, query, sessionID)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -425,7 +443,7 @@ func (r *chatRepository) IsUserPartOfSession(ctx context.Context, userID, sessio
 
 	// query := `SELECT COUNT(*) FROM session_participants WHERE user_id = $1 AND session_id = $2`
 
-	err := r.db.GetContext(ctx, &count, query, userID, sessionID)
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, userID, sessionID)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type connectionRepository struct {
+	db *sqlx.DB
+}
+
+func NewConnectionRepository(db *sqlx.DB) interfaces.ConnectionRepository {
+	return &connectionRepository{db: db}
+}
+
+// connectionSelect projects a connection from the point of view of the user bound to $1
+const connectionSelect = `
+		SELECT
+			pc.*,
+			o.id as other_user_id,
+			o.first_name as other_first_name,
+			o.last_name as other_last_name,
+			COALESCE(o.avatar_url, '') as other_avatar_url,
+			o.play_level as other_play_level
+		FROM player_connections pc
+		JOIN users o ON o.id = CASE WHEN pc.player1_id = $1 THEN pc.player2_id ELSE pc.player1_id END`
+
+func (r *connectionRepository) Create(ctx context.Context, connection *models.PlayerConnection) error {
+	query := `
+		INSERT INTO player_connections (
+			id, player1_id, player2_id, status, created_at, updated_at
+		) VALUES (
+			:id, :player1_id, :player2_id, :status, :created_at, :updated_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, connection)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return interfaces.ErrDuplicateConnection
+		}
+		return fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	return nil
+}
+
+func (r *connectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PlayerConnection, error) {
+	query := `SELECT * FROM player_connections WHERE id = $1`
+
+	var connection models.PlayerConnection
+	if err := conn(ctx, r.db).GetContext(ctx, &connection, query, id); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+func (r *connectionRepository) GetBetween(ctx context.Context, userID, otherID uuid.UUID) (*models.PlayerConnection, error) {
+	query := connectionSelect + `
+		WHERE (pc.player1_id = $1 AND pc.player2_id = $2)
+		OR (pc.player1_id = $2 AND pc.player2_id = $1)`
+
+	var connection models.PlayerConnection
+	if err := conn(ctx, r.db).GetContext(ctx, &connection, query, userID, otherID); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+func (r *connectionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.ConnectionStatus) error {
+	query := `
+		UPDATE player_connections SET
+			status = $1,
+			updated_at = NOW()
+		WHERE id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, status, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("connection not found")
+	}
+
+	return nil
+}
+
+func (r *connectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM player_connections WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("connection not found")
+	}
+
+	return nil
+}
+
+func (r *connectionRepository) ListConnections(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PlayerConnection, error) {
+	query := connectionSelect + `
+		WHERE (pc.player1_id = $1 OR pc.player2_id = $1)
+		AND pc.status = 'accepted'
+		ORDER BY o.first_name, o.last_name
+		LIMIT $2 OFFSET $3`
+
+	connections := []models.PlayerConnection{}
+	err := conn(ctx, r.db).SelectContext(ctx, &connections, query, userID, limit, offset)
+	return connections, err
+}
+
+func (r *connectionRepository) CountConnections(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM player_connections
+		WHERE (player1_id = $1 OR player2_id = $1)
+		AND status = 'accepted'`
+
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, userID)
+	return count, err
+}
+
+func (r *connectionRepository) ListIncomingRequests(ctx context.Context, userID uuid.UUID) ([]models.PlayerConnection, error) {
+	query := connectionSelect + `
+		WHERE pc.player2_id = $1
+		AND pc.status = 'pending'
+		ORDER BY pc.created_at DESC`
+
+	connections := []models.PlayerConnection{}
+	err := conn(ctx, r.db).SelectContext(ctx, &connections, query, userID)
+	return connections, err
+}
+
+func (r *connectionRepository) ListOutgoingRequests(ctx context.Context, userID uuid.UUID) ([]models.PlayerConnection, error) {
+	query := connectionSelect + `
+		WHERE pc.player1_id = $1
+		AND pc.status = 'pending'
+		ORDER BY pc.created_at DESC`
+
+	connections := []models.PlayerConnection{}
+	err := conn(ctx, r.db).SelectContext(ctx, &connections, query, userID)
+	return connections, err
+}
+
+func (r *connectionRepository) GetMutualConnections(ctx context.Context, userID, otherID uuid.UUID) ([]models.PlayerConnection, error) {
+	query := connectionSelect + `
+		WHERE (pc.player1_id = $1 OR pc.player2_id = $1)
+		AND pc.status = 'accepted'
+		AND o.id IN (
+			SELECT CASE WHEN player1_id = $2 THEN player2_id ELSE player1_id END
+			FROM player_connections
+			WHERE (player1_id = $2 OR player2_id = $2)
+			AND status = 'accepted'
+		)
+		ORDER BY o.first_name, o.last_name`
+
+	connections := []models.PlayerConnection{}
+	err := conn(ctx, r.db).SelectContext(ctx, &connections, query, userID, otherID)
+	return connections, err
+}
+
+func (r *connectionRepository) GetPlayingPartners(ctx context.Context, userID uuid.UUID, minSessions, limit int) ([]models.PlayingPartner, error) {
+	query := `
+		SELECT
+			u.id as user_id,
+			u.first_name,
+			u.last_name,
+			COALESCE(u.avatar_url, '') as avatar_url,
+			u.play_level,
+			COUNT(DISTINCT ps.id) as sessions_together,
+			MAX(ps.session_date) as last_played_at
+		FROM session_participants me
+		JOIN session_participants other ON other.session_id = me.session_id
+			AND other.user_id != me.user_id
+			AND other.status = 'confirmed'
+		JOIN play_sessions ps ON ps.id = me.session_id
+			AND ps.status != 'cancelled'
+			AND ps.session_date <= CURRENT_DATE
+		JOIN users u ON u.id = other.user_id
+		WHERE me.user_id = $1
+		AND me.status = 'confirmed'
+		AND u.status = 'active'
+		AND NOT EXISTS (
+			SELECT 1 FROM player_connections pc
+			WHERE (pc.player1_id = $1 AND pc.player2_id = u.id)
+			OR (pc.player1_id = u.id AND pc.player2_id = $1)
+		)
+		GROUP BY u.id, u.first_name, u.last_name, u.avatar_url, u.play_level
+		HAVING COUNT(DISTINCT ps.id) >= $2
+		ORDER BY sessions_together DESC, last_played_at DESC
+		LIMIT $3`
+
+	partners := []models.PlayingPartner{}
+	err := conn(ctx, r.db).SelectContext(ctx, &partners, query, userID, minSessions, limit)
+	return partners, err
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type courtRepository struct {
@@ -31,7 +32,7 @@ func (r *courtRepository) Create(ctx context.Context, court *models.Court) error
 			:status, :created_at, :updated_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, court)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, court)
 	return err
 }
 
@@ -43,7 +44,7 @@ func (r *courtRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Co
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	var court models.Court
-	err := r.db.GetContext(ctx, &court, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, &court, query, id)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +64,7 @@ func (r *courtRepository) GetCourtWithVenueByID(ctx context.Context, id uuid.UUI
 		WHERE c.id = $1 AND c.deleted_at IS NULL`
 
 	var court models.CourtWithVenue
-	err := r.db.GetContext(ctx, &court, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, &court, query, id)
 	if err != nil {
 		return nil, err
 	}
@@ -82,47 +83,11 @@ func (r *courtRepository) List(ctx context.Context, filters map[string]interface
 		JOIN venues v ON v.id = c.venue_id
 		WHERE c.deleted_at IS NULL`
 
-	args := []interface{}{}
-	argCount := 1
-
-	// Add filters
-	if len(filters) > 0 {
-		whereConditions := []string{}
-
-		if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("c.venue_id = $%d", argCount))
-			args = append(args, venueID)
-			argCount++
-		}
-
-		if status, ok := filters["status"].(models.CourtStatus); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("c.status = $%d", argCount))
-			args = append(args, status)
-			argCount++
-		}
-
-		if location, ok := filters["location"].(string); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("v.location ILIKE $%d", argCount))
-			args = append(args, "%"+location+"%")
-			argCount++
-		}
-
-		if priceMin, ok := filters["price_min"].(float64); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("c.price_per_hour >= $%d", argCount))
-			args = append(args, priceMin)
-			argCount++
-		}
-
-		if priceMax, ok := filters["price_max"].(float64); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("c.price_per_hour <= $%d", argCount))
-			args = append(args, priceMax)
-			argCount++
-		}
-
-		if len(whereConditions) > 0 {
-			query += " AND " + strings.Join(whereConditions, " AND ")
-		}
+	whereConditions, args := courtFilterConditions(filters)
+	if len(whereConditions) > 0 {
+		query += " AND " + strings.Join(whereConditions, " AND ")
 	}
+	argCount := len(args) + 1
 
 	// Add ordering
 	query += " ORDER BY c.created_at DESC"
@@ -140,7 +105,7 @@ func (r *courtRepository) List(ctx context.Context, filters map[string]interface
 		argCount++
 	}
 	var courts []models.Court
-	err := r.db.SelectContext(ctx, &courts, query, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +123,7 @@ func (r *courtRepository) Update(ctx context.Context, court *models.Court) error
 			updated_at = :updated_at
 		WHERE id = :id AND deleted_at IS NULL`
 
-	result, err := r.db.NamedExecContext(ctx, query, court)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, court)
 	if err != nil {
 		return err
 	}
@@ -182,7 +147,7 @@ func (r *courtRepository) Delete(ctx context.Context, id uuid.UUID) error {
 			updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -205,10 +170,10 @@ func (r *courtRepository) GetByVenue(ctx context.Context, venueID uuid.UUID) ([]
 			*
 		FROM courts
 		WHERE venue_id = $1 AND deleted_at IS NULL
-		ORDER BY c.name ASC`
+		ORDER BY name ASC`
 
 	var courts []models.Court
-	err := r.db.SelectContext(ctx, &courts, query, venueID)
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, venueID)
 	return courts, err
 }
 
@@ -225,7 +190,7 @@ func (r *courtRepository) GetCourtWithVenueByVenue(ctx context.Context, venueID
 		ORDER BY c.name ASC`
 
 	var courts []models.CourtWithVenue
-	err := r.db.SelectContext(ctx, &courts, query, venueID)
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, venueID)
 	return courts, err
 }
 
@@ -236,7 +201,7 @@ func (r *courtRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 			updated_at = NOW()
 		WHERE id = $2 AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, status, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, status, id)
 	if err != nil {
 		return err
 	}
@@ -280,7 +245,7 @@ func (r *courtRepository) GetAvailableCourts(ctx context.Context, venueID uuid.U
 		ORDER BY c.name ASC`
 
 	var courts []models.Court
-	err := r.db.SelectContext(ctx, &courts, query, venueID, date, startTime, endTime)
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, venueID, date, startTime, endTime)
 	return courts, err
 }
 
@@ -291,37 +256,142 @@ func (r *courtRepository) Count(ctx context.Context, filters map[string]interfac
 		JOIN venues v ON v.id = c.venue_id
 		WHERE c.deleted_at IS NULL`
 
+	whereConditions, args := courtFilterConditions(filters)
+	if len(whereConditions) > 0 {
+		query += " AND " + strings.Join(whereConditions, " AND ")
+	}
+
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, args...)
+	return count, err
+}
+
+// courtFilterConditions builds the WHERE conditions shared by List and Count so
+// that paginated totals always match the listed rows.
+func (r *courtRepository) LockCourts(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	courtIDs := make([]string, len(ids))
+	for i, id := range ids {
+		courtIDs[i] = id.String()
+	}
+
+	// Lock in a stable order so concurrent callers cannot deadlock each other
+	query := `SELECT id FROM courts WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, pq.Array(courtIDs))
+	return err
+}
+
+func (r *courtRepository) SearchCourts(ctx context.Context, facilities []string, latitude, longitude *float64, radiusKm float64) ([]models.CourtSearchResult, error) {
+	// Distances come from earthdistance; earth_box lets the radius use the venues' GiST index
+	query := `
+		SELECT *
+		FROM (
+			SELECT
+				c.*,
+				v.name as venue_name,
+				v.location as venue_location,
+				v.status as venue_status,
+				v.address as venue_address,
+				v.open_range as venue_open_range,
+				v.rating as venue_rating,
+				v.latitude as venue_latitude,
+				v.longitude as venue_longitude,
+				CASE WHEN $1::float8 IS NULL OR $2::float8 IS NULL THEN NULL
+				ELSE earth_distance(ll_to_earth($1::float8, $2::float8), ll_to_earth(v.latitude, v.longitude)) / 1000
+				END as distance_km
+			FROM courts c
+			JOIN venues v ON v.id = c.venue_id
+			WHERE c.deleted_at IS NULL
+			AND v.deleted_at IS NULL
+			AND v.status = 'active'
+			AND (cardinality($3::text[]) = 0 OR v.id IN (
+				SELECT vf.venue_id
+				FROM venues_facilities vf
+				JOIN facilities f ON f.id = vf.facility_id
+				WHERE f.name = ANY($3::text[])
+				GROUP BY vf.venue_id
+				HAVING COUNT(DISTINCT f.name) = cardinality($3::text[])
+			))
+			AND ($4::float8 = 0 OR (
+				earth_box(ll_to_earth($1::float8, $2::float8), $4::float8 * 1000) @> ll_to_earth(v.latitude, v.longitude)
+				AND NOT (v.latitude = 0 AND v.longitude = 0)
+			))
+		) found
+		WHERE $4::float8 = 0 OR distance_km <= $4::float8
+		ORDER BY venue_id, name ASC`
+
+	if facilities == nil {
+		facilities = []string{}
+	}
+
+	courts := []models.CourtSearchResult{}
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, latitude, longitude, pq.Array(facilities), radiusKm)
+	return courts, err
+}
+
+func courtFilterConditions(filters map[string]interface{}) ([]string, []interface{}) {
+	whereConditions := []string{}
 	args := []interface{}{}
 	argCount := 1
 
-	// Add filters
-	if len(filters) > 0 {
-		whereConditions := []string{}
-
-		if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("c.venue_id = $%d", argCount))
-			args = append(args, venueID)
-			argCount++
-		}
-
-		if status, ok := filters["status"].(models.CourtStatus); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("c.status = $%d", argCount))
-			args = append(args, status)
-			argCount++
-		}
-
-		if location, ok := filters["location"].(string); ok {
-			whereConditions = append(whereConditions, fmt.Sprintf("v.location ILIKE $%d", argCount))
-			args = append(args, "%"+location+"%")
-			argCount++
-		}
-
-		if len(whereConditions) > 0 {
-			query += " AND " + strings.Join(whereConditions, " AND ")
-		}
+	if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.venue_id = $%d", argCount))
+		args = append(args, venueID)
+		argCount++
 	}
 
-	var count int
-	err := r.db.GetContext(ctx, &count, query, args...)
-	return count, err
+	if ownerID, ok := filters["owner_id"].(uuid.UUID); ok {
+		whereConditions = append(whereConditions, fmt.Sprintf("v.owner_id = $%d", argCount))
+		args = append(args, ownerID)
+		argCount++
+	}
+
+	if status, ok := filters["status"].(models.CourtStatus); ok {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.status = $%d", argCount))
+		args = append(args, status)
+		argCount++
+	}
+
+	if location, ok := filters["location"].(string); ok {
+		whereConditions = append(whereConditions, fmt.Sprintf("v.location ILIKE $%d", argCount))
+		args = append(args, "%"+location+"%")
+		argCount++
+	}
+
+	if priceMin, ok := filters["price_min"].(float64); ok {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.price_per_hour >= $%d", argCount))
+		args = append(args, priceMin)
+		argCount++
+	}
+
+	if priceMax, ok := filters["price_max"].(float64); ok {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.price_per_hour <= $%d", argCount))
+		args = append(args, priceMax)
+	}
+
+	return whereConditions, args
+}
+
+func (r *courtRepository) HasUpcomingReservations(ctx context.Context, courtID uuid.UUID, from time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM court_bookings
+			WHERE court_id = $1
+			AND booking_date >= $2
+			AND status != 'cancelled'
+		) OR EXISTS (
+			SELECT 1 FROM session_courts sc
+			JOIN play_sessions ps ON ps.id = sc.session_id
+			WHERE sc.court_id = $1
+			AND ps.session_date >= $2
+			AND ps.status NOT IN ('cancelled', 'completed')
+		)`
+
+	var reserved bool
+	err := conn(ctx, r.db).GetContext(ctx, &reserved, query, courtID, from)
+	return reserved, err
 }
@@ -21,7 +21,7 @@ func (r *facilityRepository) GetFacilities(ctx context.Context) ([]models.Facili
 
 	query := `SELECT * FROM facilities`
 
-	err := r.db.SelectContext(ctx, &facilities, query)
+	err := conn(ctx, r.db).SelectContext(ctx, &facilities, query)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +34,7 @@ func (r *facilityRepository) GetFacilityByID(ctx context.Context, id uuid.UUID)
 
 	query := `SELECT * FROM facilities WHERE id = $1`
 
-	err := r.db.GetContext(ctx, &facility, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, &facility, query, id)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func (r *facilityRepository) GetFacilityByID(ctx context.Context, id uuid.UUID)
 func (r *facilityRepository) CreateFacility(ctx context.Context, facility *models.Facility) error {
 	query := `INSERT INTO facilities (id, name) VALUES ($1, $2)`
 
-	_, err := r.db.ExecContext(ctx, query, facility.ID, facility.Name)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, facility.ID, facility.Name)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ func (r *facilityRepository) CreateFacility(ctx context.Context, facility *model
 func (r *facilityRepository) UpdateFacility(ctx context.Context, facility *models.Facility) error {
 	query := `UPDATE facilities SET name = $1 WHERE id = $2`
 
-	_, err := r.db.ExecContext(ctx, query, facility.Name, facility.ID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, facility.Name, facility.ID)
 	if err != nil {
 		return err
 	}
@@ -67,10 +67,10 @@ func (r *facilityRepository) UpdateFacility(ctx context.Context, facility *model
 func (r *facilityRepository) DeleteFacility(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM facilities WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
 
 	return nil
-}
\ No newline at end of file
+}
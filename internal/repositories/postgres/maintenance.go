@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type maintenanceRepository struct {
+	db *sqlx.DB
+}
+
+func NewMaintenanceRepository(db *sqlx.DB) interfaces.MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+func (r *maintenanceRepository) Create(ctx context.Context, window *models.MaintenanceWindow) error {
+	query := `
+		INSERT INTO court_maintenance_windows (id, court_id, starts_at, ends_at, reason, created_by, created_at)
+		VALUES (:id, :court_id, :starts_at, :ends_at, :reason, :created_by, :created_at)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, window)
+	return err
+}
+
+func (r *maintenanceRepository) Delete(ctx context.Context, courtID, id uuid.UUID) error {
+	query := `DELETE FROM court_maintenance_windows WHERE id = $1 AND court_id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, courtID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *maintenanceRepository) ListUpcoming(ctx context.Context, venueID uuid.UUID, courtID *uuid.UUID, from time.Time) ([]models.MaintenanceWindow, error) {
+	query := `
+		SELECT m.*, c.name AS court_name
+		FROM court_maintenance_windows m
+		JOIN courts c ON c.id = m.court_id
+		WHERE c.venue_id = $1
+		AND ($2::uuid IS NULL OR m.court_id = $2)
+		AND m.ends_at > $3
+		ORDER BY m.starts_at ASC`
+
+	windows := []models.MaintenanceWindow{}
+	err := conn(ctx, r.db).SelectContext(ctx, &windows, query, venueID, courtID, from)
+	return windows, err
+}
+
+func (r *maintenanceRepository) GetOverlapping(ctx context.Context, courtID uuid.UUID, start, end time.Time) ([]models.MaintenanceWindow, error) {
+	query := `
+		SELECT m.*, c.name AS court_name
+		FROM court_maintenance_windows m
+		JOIN courts c ON c.id = m.court_id
+		WHERE m.court_id = $1 AND m.starts_at < $3 AND m.ends_at > $2
+		ORDER BY m.starts_at ASC`
+
+	windows := []models.MaintenanceWindow{}
+	err := conn(ctx, r.db).SelectContext(ctx, &windows, query, courtID, start, end)
+	return windows, err
+}
+
+func (r *maintenanceRepository) GetVenueOverlapping(ctx context.Context, venueID uuid.UUID, start, end time.Time) ([]models.MaintenanceWindow, error) {
+	query := `
+		SELECT m.*, c.name AS court_name
+		FROM court_maintenance_windows m
+		JOIN courts c ON c.id = m.court_id
+		WHERE c.venue_id = $1 AND m.starts_at < $3 AND m.ends_at > $2
+		ORDER BY m.starts_at ASC`
+
+	windows := []models.MaintenanceWindow{}
+	err := conn(ctx, r.db).SelectContext(ctx, &windows, query, venueID, start, end)
+	return windows, err
+}
+
+func (r *maintenanceRepository) GetCourtsOverlapping(ctx context.Context, courtIDs []uuid.UUID, start, end time.Time) ([]models.MaintenanceWindow, error) {
+	query := `
+		SELECT m.*, c.name AS court_name
+		FROM court_maintenance_windows m
+		JOIN courts c ON c.id = m.court_id
+		WHERE m.court_id = ANY($1::uuid[]) AND m.starts_at < $3 AND m.ends_at > $2
+		ORDER BY m.starts_at ASC`
+
+	windows := []models.MaintenanceWindow{}
+	err := conn(ctx, r.db).SelectContext(ctx, &windows, query, models.UUIDList(courtIDs), start, end)
+	return windows, err
+}
+
+func (r *maintenanceRepository) GetActive(ctx context.Context, at time.Time) ([]models.MaintenanceWindow, error) {
+	query := `
+		SELECT m.*, c.name AS court_name
+		FROM court_maintenance_windows m
+		JOIN courts c ON c.id = m.court_id
+		WHERE m.starts_at <= $1 AND m.ends_at > $1`
+
+	windows := []models.MaintenanceWindow{}
+	err := conn(ctx, r.db).SelectContext(ctx, &windows, query, at)
+	return windows, err
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type pricingRepository struct {
+	db *sqlx.DB
+}
+
+func NewPricingRepository(db *sqlx.DB) interfaces.PricingRepository {
+	return &pricingRepository{db: db}
+}
+
+func (r *pricingRepository) CreateRule(ctx context.Context, rule *models.PricingRule) error {
+	query := `
+		INSERT INTO venue_pricing_rules (
+			id, venue_id, court_id, name, weekdays, holidays_only, start_time, end_time,
+			start_date, end_date, price_per_hour, priority, created_at
+		) VALUES (
+			:id, :venue_id, :court_id, :name, :weekdays, :holidays_only, :start_time, :end_time,
+			:start_date, :end_date, :price_per_hour, :priority, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, rule)
+	return err
+}
+
+func (r *pricingRepository) ListRules(ctx context.Context, venueID uuid.UUID) ([]models.PricingRule, error) {
+	query := `
+		SELECT *
+		FROM venue_pricing_rules
+		WHERE venue_id = $1
+		ORDER BY priority DESC, start_time ASC, created_at ASC`
+
+	rules := []models.PricingRule{}
+	err := conn(ctx, r.db).SelectContext(ctx, &rules, query, venueID)
+	return rules, err
+}
+
+func (r *pricingRepository) DeleteRule(ctx context.Context, venueID, id uuid.UUID) error {
+	query := `DELETE FROM venue_pricing_rules WHERE id = $1 AND venue_id = $2`
+
+	return r.deleteOne(ctx, query, id, venueID)
+}
+
+func (r *pricingRepository) CreateHoliday(ctx context.Context, holiday *models.Holiday) error {
+	query := `
+		INSERT INTO venue_holidays (id, venue_id, holiday_date, name, created_at)
+		VALUES (:id, :venue_id, :holiday_date, :name, :created_at)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, holiday)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return interfaces.ErrDuplicateHoliday
+		}
+		return fmt.Errorf("failed to create holiday: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pricingRepository) ListHolidays(ctx context.Context, venueID uuid.UUID, from time.Time) ([]models.Holiday, error) {
+	query := `
+		SELECT *
+		FROM venue_holidays
+		WHERE venue_id = $1 AND holiday_date >= $2::date
+		ORDER BY holiday_date ASC`
+
+	holidays := []models.Holiday{}
+	err := conn(ctx, r.db).SelectContext(ctx, &holidays, query, venueID, from)
+	return holidays, err
+}
+
+func (r *pricingRepository) DeleteHoliday(ctx context.Context, venueID, id uuid.UUID) error {
+	query := `DELETE FROM venue_holidays WHERE id = $1 AND venue_id = $2`
+
+	return r.deleteOne(ctx, query, id, venueID)
+}
+
+func (r *pricingRepository) GetVenuePricing(ctx context.Context, venueID uuid.UUID, date time.Time) (*models.VenuePricing, error) {
+	rules, err := r.ListRules(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM venue_holidays WHERE venue_id = $1 AND holiday_date = $2::date)`
+
+	var holiday bool
+	if err := conn(ctx, r.db).GetContext(ctx, &holiday, query, venueID, date); err != nil {
+		return nil, err
+	}
+
+	return &models.VenuePricing{
+		Rules:   rules,
+		Holiday: holiday,
+	}, nil
+}
+
+func (r *pricingRepository) GetVenuesPricing(ctx context.Context, venueIDs []uuid.UUID, date time.Time) (map[uuid.UUID]*models.VenuePricing, error) {
+	pricing := make(map[uuid.UUID]*models.VenuePricing, len(venueIDs))
+	for _, id := range venueIDs {
+		pricing[id] = &models.VenuePricing{Rules: []models.PricingRule{}}
+	}
+
+	rulesQuery := `
+		SELECT *
+		FROM venue_pricing_rules
+		WHERE venue_id = ANY($1::uuid[])
+		ORDER BY priority DESC, start_time ASC, created_at ASC`
+
+	rules := []models.PricingRule{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rules, rulesQuery, models.UUIDList(venueIDs)); err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if p, ok := pricing[rule.VenueID]; ok {
+			p.Rules = append(p.Rules, rule)
+		}
+	}
+
+	holidaysQuery := `SELECT venue_id FROM venue_holidays WHERE venue_id = ANY($1::uuid[]) AND holiday_date = $2::date`
+
+	var holidays []uuid.UUID
+	if err := conn(ctx, r.db).SelectContext(ctx, &holidays, holidaysQuery, models.UUIDList(venueIDs), date); err != nil {
+		return nil, err
+	}
+	for _, id := range holidays {
+		if p, ok := pricing[id]; ok {
+			p.Holiday = true
+		}
+	}
+
+	return pricing, nil
+}
+
+func (r *pricingRepository) deleteOne(ctx context.Context, query string, args ...interface{}) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type promotionRepository struct {
+	db *sqlx.DB
+}
+
+func NewPromotionRepository(db *sqlx.DB) interfaces.PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	query := `
+		INSERT INTO promotions (
+			id, code, description, venue_id, court_ids, discount_type, discount_value, min_spend,
+			max_uses, max_uses_per_user, uses_count, starts_at, ends_at, active, created_by, created_at, updated_at
+		) VALUES (
+			:id, :code, :description, :venue_id, :court_ids, :discount_type, :discount_value, :min_spend,
+			:max_uses, :max_uses_per_user, :uses_count, :starts_at, :ends_at, :active, :created_by, :created_at, :updated_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, promotion)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return interfaces.ErrDuplicatePromotionCode
+		}
+		return fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	query := `SELECT * FROM promotions WHERE id = $1`
+
+	var promotion models.Promotion
+	if err := conn(ctx, r.db).GetContext(ctx, &promotion, query, id); err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (r *promotionRepository) LockByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	query := `SELECT * FROM promotions WHERE upper(code) = upper($1) FOR UPDATE`
+
+	var promotion models.Promotion
+	if err := conn(ctx, r.db).GetContext(ctx, &promotion, query, code); err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (r *promotionRepository) List(ctx context.Context, venueID *uuid.UUID) ([]models.Promotion, error) {
+	query := `
+		SELECT *
+		FROM promotions
+		WHERE $1::uuid IS NULL OR venue_id = $1
+		ORDER BY created_at DESC`
+
+	promotions := []models.Promotion{}
+	err := conn(ctx, r.db).SelectContext(ctx, &promotions, query, venueID)
+	return promotions, err
+}
+
+func (r *promotionRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE promotions SET active = false, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) CountUserRedemptions(ctx context.Context, promotionID, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2`
+
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, promotionID, userID)
+	return count, err
+}
+
+func (r *promotionRepository) Redeem(ctx context.Context, redemption *models.PromotionRedemption) error {
+	query := `
+		INSERT INTO promotion_redemptions (id, promotion_id, user_id, booking_id, discount_amount, created_at)
+		VALUES (:id, :promotion_id, :user_id, :booking_id, :discount_amount, :created_at)`
+
+	if _, err := conn(ctx, r.db).NamedExecContext(ctx, query, redemption); err != nil {
+		return fmt.Errorf("failed to record redemption: %w", err)
+	}
+
+	// The check constraint on max_uses backs up the caller's cap check
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE promotions SET uses_count = uses_count + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1`,
+		redemption.PromotionID)
+	return err
+}
+
+func (r *promotionRepository) ReleaseRedemption(ctx context.Context, bookingID uuid.UUID) error {
+	query := `
+		WITH released AS (
+			DELETE FROM promotion_redemptions WHERE booking_id = $1 RETURNING promotion_id
+		)
+		UPDATE promotions p
+		SET uses_count = p.uses_count - 1, updated_at = CURRENT_TIMESTAMP
+		FROM released
+		WHERE p.id = released.promotion_id`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, bookingID)
+	return err
+}
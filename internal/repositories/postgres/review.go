@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type playerReviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewPlayerReviewRepository(db *sqlx.DB) interfaces.PlayerReviewRepository {
+	return &playerReviewRepository{db: db}
+}
+
+const playerReviewSelect = `
+		SELECT
+			pr.*,
+			reviewer.first_name as reviewer_first_name,
+			reviewer.last_name as reviewer_last_name,
+			COALESCE(reviewer.avatar_url, '') as reviewer_avatar_url,
+			reviewed.first_name as reviewed_first_name,
+			reviewed.last_name as reviewed_last_name,
+			COALESCE(reviewed.avatar_url, '') as reviewed_avatar_url,
+			ps.title as session_title,
+			ps.session_date as session_date
+		FROM player_reviews pr
+		JOIN users reviewer ON reviewer.id = pr.reviewer_id
+		JOIN users reviewed ON reviewed.id = pr.reviewed_id
+		JOIN play_sessions ps ON ps.id = pr.session_id`
+
+func (r *playerReviewRepository) Create(ctx context.Context, review *models.PlayerReview) error {
+	query := `
+		INSERT INTO player_reviews (
+			id, reviewer_id, reviewed_id, session_id, rating, comment, created_at
+		) VALUES (
+			:id, :reviewer_id, :reviewed_id, :session_id, :rating, :comment, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, review)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return interfaces.ErrDuplicateReview
+		}
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return nil
+}
+
+func (r *playerReviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PlayerReview, error) {
+	query := playerReviewSelect + `
+		WHERE pr.id = $1`
+
+	var review models.PlayerReview
+	if err := conn(ctx, r.db).GetContext(ctx, &review, query, id); err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *playerReviewRepository) Exists(ctx context.Context, reviewerID, reviewedID, sessionID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM player_reviews
+			WHERE reviewer_id = $1 AND reviewed_id = $2 AND session_id = $3
+		)`
+
+	var exists bool
+	err := conn(ctx, r.db).GetContext(ctx, &exists, query, reviewerID, reviewedID, sessionID)
+	return exists, err
+}
+
+func (r *playerReviewRepository) GetReceived(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PlayerReview, error) {
+	query := playerReviewSelect + `
+		WHERE pr.reviewed_id = $1
+		ORDER BY pr.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	reviews := []models.PlayerReview{}
+	err := conn(ctx, r.db).SelectContext(ctx, &reviews, query, userID, limit, offset)
+	return reviews, err
+}
+
+func (r *playerReviewRepository) GetGiven(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PlayerReview, error) {
+	query := playerReviewSelect + `
+		WHERE pr.reviewer_id = $1
+		ORDER BY pr.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	reviews := []models.PlayerReview{}
+	err := conn(ctx, r.db).SelectContext(ctx, &reviews, query, userID, limit, offset)
+	return reviews, err
+}
+
+func (r *playerReviewRepository) CountGiven(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM player_reviews WHERE reviewer_id = $1`
+
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, query, userID)
+	return count, err
+}
+
+func (r *playerReviewRepository) GetRatingSummary(ctx context.Context, userID uuid.UUID) (*models.PlayerRatingSummary, error) {
+	query := `
+		SELECT
+			COALESCE(AVG(rating), 0) as avg_rating,
+			COUNT(*) as total_reviews
+		FROM player_reviews
+		WHERE reviewed_id = $1`
+
+	var summary models.PlayerRatingSummary
+	if err := conn(ctx, r.db).GetContext(ctx, &summary, query, userID); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (r *playerReviewRepository) GetReviewedIDs(ctx context.Context, reviewerID, sessionID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT reviewed_id
+		FROM player_reviews
+		WHERE reviewer_id = $1 AND session_id = $2`
+
+	ids := []uuid.UUID{}
+	err := conn(ctx, r.db).SelectContext(ctx, &ids, query, reviewerID, sessionID)
+	return ids, err
+}
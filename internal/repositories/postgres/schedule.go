@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type scheduleRepository struct {
+	db *sqlx.DB
+}
+
+func NewScheduleRepository(db *sqlx.DB) interfaces.ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) CreateOverride(ctx context.Context, override *models.ScheduleOverride) error {
+	query := `
+		INSERT INTO venue_schedule_overrides (
+			id, venue_id, court_id, override_date, closed, start_time, end_time, reason, created_by, created_at
+		) VALUES (
+			:id, :venue_id, :court_id, :override_date, :closed, :start_time, :end_time, :reason, :created_by, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, override)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return interfaces.ErrDuplicateScheduleOverride
+		}
+		return fmt.Errorf("failed to create schedule override: %w", err)
+	}
+
+	return nil
+}
+
+func (r *scheduleRepository) GetOverride(ctx context.Context, venueID, id uuid.UUID) (*models.ScheduleOverride, error) {
+	query := `SELECT * FROM venue_schedule_overrides WHERE id = $1 AND venue_id = $2`
+
+	var override models.ScheduleOverride
+	if err := conn(ctx, r.db).GetContext(ctx, &override, query, id, venueID); err != nil {
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *scheduleRepository) ListOverrides(ctx context.Context, venueID uuid.UUID, from time.Time) ([]models.ScheduleOverride, error) {
+	query := `
+		SELECT *
+		FROM venue_schedule_overrides
+		WHERE venue_id = $1 AND override_date >= $2::date
+		ORDER BY override_date ASC, start_time ASC NULLS FIRST`
+
+	overrides := []models.ScheduleOverride{}
+	err := conn(ctx, r.db).SelectContext(ctx, &overrides, query, venueID, from)
+	return overrides, err
+}
+
+func (r *scheduleRepository) DeleteOverride(ctx context.Context, venueID, id uuid.UUID) error {
+	query := `DELETE FROM venue_schedule_overrides WHERE id = $1 AND venue_id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, venueID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *scheduleRepository) GetDateOverrides(ctx context.Context, venueID uuid.UUID, date time.Time) ([]models.ScheduleOverride, error) {
+	query := `
+		SELECT *
+		FROM venue_schedule_overrides
+		WHERE venue_id = $1 AND override_date = $2::date
+		ORDER BY created_at ASC`
+
+	overrides := []models.ScheduleOverride{}
+	err := conn(ctx, r.db).SelectContext(ctx, &overrides, query, venueID, date)
+	return overrides, err
+}
+
+func (r *scheduleRepository) GetRangeOverrides(ctx context.Context, venueID uuid.UUID, from, to time.Time) ([]models.ScheduleOverride, error) {
+	query := `
+		SELECT *
+		FROM venue_schedule_overrides
+		WHERE venue_id = $1 AND override_date BETWEEN $2::date AND $3::date
+		ORDER BY override_date ASC, created_at ASC`
+
+	overrides := []models.ScheduleOverride{}
+	err := conn(ctx, r.db).SelectContext(ctx, &overrides, query, venueID, from, to)
+	return overrides, err
+}
+
+func (r *scheduleRepository) GetVenuesDateOverrides(ctx context.Context, venueIDs []uuid.UUID, date time.Time) ([]models.ScheduleOverride, error) {
+	query := `
+		SELECT *
+		FROM venue_schedule_overrides
+		WHERE venue_id = ANY($1::uuid[]) AND override_date = $2::date
+		ORDER BY created_at ASC`
+
+	overrides := []models.ScheduleOverride{}
+	err := conn(ctx, r.db).SelectContext(ctx, &overrides, query, models.UUIDList(venueIDs), date)
+	return overrides, err
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"badbuddy/internal/domain/models"
 	"badbuddy/internal/repositories/interfaces"
@@ -28,16 +29,16 @@ func (r *sessionRepository) Create(ctx context.Context, session *models.Session)
 			session_date, start_time, end_time, player_level,
 			max_participants, cost_per_person, allow_cancellation,
 			cancellation_deadline_hours, is_public, status,
-			created_at, updated_at
+			series_id, created_at, updated_at
 		) VALUES (
 			:id, :host_id, :venue_id, :title, :description,
 			:session_date, :start_time, :end_time, :player_level,
 			:max_participants, :cost_per_person, :allow_cancellation,
 			:cancellation_deadline_hours, :is_public, :status,
-			:created_at, :updated_at
+			:series_id, :created_at, :updated_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, session)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, session)
 	if err != nil {
 		return err
 	}
@@ -45,6 +46,13 @@ func (r *sessionRepository) Create(ctx context.Context, session *models.Session)
 	return nil
 }
 
+func (r *sessionRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	query := `SELECT id FROM play_sessions WHERE id = $1 FOR UPDATE`
+
+	var lockedID uuid.UUID
+	return conn(ctx, r.db).GetContext(ctx, &lockedID, query, id)
+}
+
 func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SessionDetail, error) {
 	query := `
 		SELECT 
@@ -62,7 +70,7 @@ func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level`
 
 	session := &models.SessionDetail{}
-	err := r.db.GetContext(ctx, session, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, session, query, id)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +83,7 @@ func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		WHERE sp.session_id = $1
 		ORDER BY sp.joined_at`
 
-	err = r.db.SelectContext(ctx, &session.Participants, participantsQuery, id)
+	err = conn(ctx, r.db).SelectContext(ctx, &session.Participants, participantsQuery, id)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +94,12 @@ func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		FROM session_rules
 		WHERE session_id = $1`
 
-	err = r.db.SelectContext(ctx, &session.Rules, rulesQuery, id)
+	err = conn(ctx, r.db).SelectContext(ctx, &session.Rules, rulesQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Courts, err = r.GetSessionCourts(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +125,7 @@ func (r *sessionRepository) Update(ctx context.Context, session *models.Session)
 			updated_at = :updated_at
 		WHERE id = :id`
 
-	result, err := r.db.NamedExecContext(ctx, query, session)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, session)
 	if err != nil {
 		return err
 	}
@@ -179,7 +192,7 @@ func (r *sessionRepository) List(ctx context.Context, filters map[string]interfa
 	)
 
 	var sessions []models.SessionDetail
-	err := r.db.SelectContext(ctx, &sessions, query, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, args...)
 	return sessions, err
 }
 func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error) {
@@ -249,7 +262,7 @@ func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filt
 	)
 
 	sessions := []models.SessionDetail{}
-	err := r.db.SelectContext(ctx, &sessions, query, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search sessions: %w", err)
 	}
@@ -265,7 +278,7 @@ func (r *sessionRepository) AddParticipant(ctx context.Context, participant *mod
 			:id, :session_id, :user_id, :status, :joined_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, participant)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, participant)
 	return err
 }
 
@@ -278,7 +291,7 @@ func (r *sessionRepository) UpdateParticipantStatus(ctx context.Context, session
 			cancelled_at = CASE WHEN :status = 'cancelled' THEN NOW() ELSE cancelled_at END
 		WHERE session_id = :session_id AND user_id = :user_id`
 
-	result, err := r.db.NamedExecContext(ctx, query, map[string]interface{}{
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, map[string]interface{}{
 		"status":     status,
 		"session_id": sessionID,
 		"user_id":    userID,
@@ -309,7 +322,7 @@ func (r *sessionRepository) GetParticipants(ctx context.Context, sessionID uuid.
 		ORDER BY sp.joined_at`
 
 	var participants []models.SessionParticipant
-	err := r.db.SelectContext(ctx, &participants, query, sessionID)
+	err := conn(ctx, r.db).SelectContext(ctx, &participants, query, sessionID)
 	return participants, err
 }
 
@@ -342,7 +355,7 @@ func (r *sessionRepository) GetUserSessions(ctx context.Context, userID uuid.UUI
 	)
 
 	var sessions []models.SessionDetail
-	err := r.db.SelectContext(ctx, &sessions, query, userID)
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, userID)
 	return sessions, err
 }
 func (r *sessionRepository) GetMyJoinedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error) {
@@ -374,7 +387,7 @@ func (r *sessionRepository) GetMyJoinedSessions(ctx context.Context, userID uuid
 	)
 
 	var sessions []models.SessionDetail
-	err := r.db.SelectContext(ctx, &sessions, query, userID)
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, userID)
 	return sessions, err
 }
 
@@ -406,7 +419,7 @@ func (r *sessionRepository) GetMyHostedSessions(ctx context.Context, userID uuid
 	)
 
 	var sessions []models.SessionDetail
-	err := r.db.SelectContext(ctx, &sessions, query, userID)
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, userID)
 	return sessions, err
 }
 func (r *sessionRepository) GetJoinStatus(ctx context.Context, userID, venueID uuid.UUID) (models.JoinStatus, error) {
@@ -422,7 +435,7 @@ func (r *sessionRepository) GetJoinStatus(ctx context.Context, userID, venueID u
 		WHERE ps.id = $2`
 
 	var status sql.NullString
-	err := r.db.GetContext(ctx, &status, query, userID, venueID)
+	err := conn(ctx, r.db).GetContext(ctx, &status, query, userID, venueID)
 	if err != nil {
 		return "", fmt.Errorf("failed to query join status: %w", err)
 	}
@@ -433,3 +446,107 @@ func (r *sessionRepository) GetJoinStatus(ctx context.Context, userID, venueID u
 
 	return models.JoinStatus(status.String), nil
 }
+
+func (r *sessionRepository) AddSessionCourt(ctx context.Context, sessionCourt *models.SessionCourt) error {
+	query := `
+		INSERT INTO session_courts (
+			id, session_id, court_id, created_at
+		) VALUES (
+			:id, :session_id, :court_id, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, sessionCourt)
+	return err
+}
+
+func (r *sessionRepository) GetSessionCourts(ctx context.Context, sessionID uuid.UUID) ([]models.SessionCourt, error) {
+	query := `
+		SELECT sc.*, c.name as court_name
+		FROM session_courts sc
+		JOIN courts c ON c.id = sc.court_id
+		WHERE sc.session_id = $1
+		ORDER BY c.name`
+
+	courts := []models.SessionCourt{}
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, sessionID)
+	return courts, err
+}
+
+func (r *sessionRepository) ReleaseSessionCourts(ctx context.Context, sessionID uuid.UUID) error {
+	query := `DELETE FROM session_courts WHERE session_id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, sessionID)
+	return err
+}
+
+func (r *sessionRepository) RemoveSessionCourt(ctx context.Context, sessionID, courtID uuid.UUID) error {
+	query := `DELETE FROM session_courts WHERE session_id = $1 AND court_id = $2`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, sessionID, courtID)
+	return err
+}
+
+func (r *sessionRepository) GetCourtSessions(ctx context.Context, courtID uuid.UUID, date time.Time) ([]models.Session, error) {
+	query := `
+		SELECT
+			ps.id, ps.host_id, ps.venue_id, ps.title,
+			ps.session_date, ps.start_time, ps.end_time, ps.status
+		FROM play_sessions ps
+		JOIN session_courts sc ON sc.session_id = ps.id
+		WHERE sc.court_id = $1
+		AND ps.session_date = $2
+		AND ps.status != 'cancelled'
+		ORDER BY ps.start_time`
+
+	sessions := []models.Session{}
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, courtID, date)
+	return sessions, err
+}
+
+func (r *sessionRepository) GetCourtSessionsBetween(ctx context.Context, courtID uuid.UUID, first, last time.Time) ([]models.Session, error) {
+	query := `
+		SELECT
+			ps.id, ps.host_id, ps.venue_id, ps.title,
+			ps.session_date, ps.start_time, ps.end_time, ps.status
+		FROM play_sessions ps
+		JOIN session_courts sc ON sc.session_id = ps.id
+		WHERE sc.court_id = $1
+		AND ps.session_date BETWEEN $2 AND $3
+		AND ps.status NOT IN ('cancelled', 'completed')
+		ORDER BY ps.session_date, ps.start_time`
+
+	sessions := []models.Session{}
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, courtID, first, last)
+	return sessions, err
+}
+
+func (r *sessionRepository) CreateSeries(ctx context.Context, series *models.SessionSeries) error {
+	query := `
+		INSERT INTO session_series (
+			id, host_id, venue_id, weekdays, interval_weeks,
+			starts_on, until_date, occurrence_count, created_at
+		) VALUES (
+			:id, :host_id, :venue_id, :weekdays, :interval_weeks,
+			:starts_on, :until_date, :occurrence_count, :created_at
+		)`
+
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, series)
+	return err
+}
+
+// GetSeriesSessions lists the occurrences of a series still open for play on or after a date
+func (r *sessionRepository) GetSeriesSessions(ctx context.Context, seriesID uuid.UUID, from time.Time) ([]models.Session, error) {
+	query := `
+		SELECT
+			ps.id, ps.host_id, ps.venue_id, ps.title,
+			ps.session_date, ps.start_time, ps.end_time, ps.status, ps.series_id
+		FROM play_sessions ps
+		WHERE ps.series_id = $1
+		AND ps.session_date >= $2
+		AND ps.status IN ('open', 'full')
+		ORDER BY ps.session_date, ps.start_time`
+
+	sessions := []models.Session{}
+	err := conn(ctx, r.db).SelectContext(ctx, &sessions, query, seriesID, from)
+	return sessions, err
+}
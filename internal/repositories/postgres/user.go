@@ -55,7 +55,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 		user.Status = models.UserStatusActive
 	}
 
-	_, err := r.db.NamedExecContext(ctx, query, user)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, user)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
@@ -73,7 +73,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	var user models.User
-	err := r.db.GetContext(ctx, &user, `
+	err := conn(ctx, r.db).GetContext(ctx, &user, `
         SELECT * FROM users 
         WHERE id = $1 AND status != $2`,
 		id, models.UserStatusInactive)
@@ -96,7 +96,7 @@ func (r *userRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]
 		stringIDs[i] = id.String()
 	}
 
-	err := r.db.SelectContext(ctx, &users, `
+	err := conn(ctx, r.db).SelectContext(ctx, &users, `
 		SELECT 
 			id, email, first_name, last_name, phone,
 			play_level, location, bio, avatar_url, status,
@@ -114,7 +114,7 @@ func (r *userRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := r.db.GetContext(ctx, &user, `
+	err := conn(ctx, r.db).GetContext(ctx, &user, `
         SELECT * FROM users 
         WHERE email = $1 AND status != $2`,
 		email, models.UserStatusInactive)
@@ -142,7 +142,7 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 			role = :role
 		WHERE id = :id AND status != 'inactive'`
 
-	result, err := r.db.NamedExecContext(ctx, query, user)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, user)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -161,34 +161,7 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 
 func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserProfile, error) {
 	query := `
-        WITH session_counts AS (
-            SELECT 
-                CASE 
-                    WHEN sp1.user_id < sp2.user_id THEN sp1.user_id 
-                    ELSE sp2.user_id 
-                END as player1_id,
-                CASE 
-                    WHEN sp1.user_id < sp2.user_id THEN sp2.user_id 
-                    ELSE sp1.user_id 
-                END as player2_id,
-                COUNT(DISTINCT sp1.session_id) as sessions_together
-            FROM session_participants sp1
-            JOIN session_participants sp2 ON sp1.session_id = sp2.session_id 
-                AND sp1.user_id != sp2.user_id
-            JOIN play_sessions ps ON ps.id = sp1.session_id 
-                AND ps.status != 'cancelled'
-            GROUP BY 
-                CASE 
-                    WHEN sp1.user_id < sp2.user_id THEN sp1.user_id 
-                    ELSE sp2.user_id 
-                END,
-                CASE 
-                    WHEN sp1.user_id < sp2.user_id THEN sp2.user_id 
-                    ELSE sp1.user_id 
-                END
-            HAVING COUNT(DISTINCT sp1.session_id) >= 3
-        ),
-        user_stats AS (
+        WITH user_stats AS (
             SELECT 
                 u.*,
                 COUNT(DISTINCT ps.id) FILTER (
@@ -201,32 +174,34 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*mod
                     AND ps.host_id != u.id
                 ) as joined_sessions,
                 
-                COALESCE(AVG(pr.rating), 0) as avg_rating,
+                COALESCE((
+                    SELECT AVG(pr.rating)
+                    FROM player_reviews pr
+                    WHERE pr.reviewed_id = u.id
+                ), 0) as avg_rating,
                 
-                COUNT(DISTINCT pr.id) as total_reviews,
+                (
+                    SELECT COUNT(*)
+                    FROM player_reviews pr
+                    WHERE pr.reviewed_id = u.id
+                ) as total_reviews,
                 
-                COALESCE((
-                    SELECT COUNT(DISTINCT 
-                        CASE 
-                            WHEN sc.player1_id = u.id THEN sc.player2_id 
-                            ELSE sc.player1_id 
-                        END
-                    )
-                    FROM session_counts sc
-                    WHERE sc.player1_id = u.id 
-                    OR sc.player2_id = u.id
-                ), 0) as regular_partners
+                (
+                    SELECT COUNT(*)
+                    FROM player_connections pc
+                    WHERE (pc.player1_id = u.id OR pc.player2_id = u.id)
+                    AND pc.status = 'accepted'
+                ) as regular_partners
             FROM users u
             LEFT JOIN play_sessions ps ON ps.host_id = u.id
             LEFT JOIN session_participants sp ON sp.user_id = u.id
-            LEFT JOIN player_reviews pr ON pr.reviewed_id = u.id
             WHERE u.id = $1 AND u.status != $2
             GROUP BY u.id
         )
         SELECT * FROM user_stats;`
 
 	var profile models.UserProfile
-	err := r.db.GetContext(ctx, &profile, query, userID, models.UserStatusInactive)
+	err := conn(ctx, r.db).GetContext(ctx, &profile, query, userID, models.UserStatusInactive)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrUserNotFound
@@ -238,7 +213,7 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*mod
 }
 
 func (r *userRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID) error {
-	result, err := r.db.ExecContext(ctx, `
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
         UPDATE users 
         SET last_active_at = CURRENT_TIMESTAMP 
         WHERE id = $1 AND status != $2`,
@@ -321,7 +296,7 @@ func (r *userRepository) SearchUsers(ctx context.Context, query string, filters
 	args = append(args, filters.Limit, filters.Offset)
 
 	var users []models.User
-	err := r.db.SelectContext(ctx, &users, queryBuilder, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &users, queryBuilder, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search users: %w", err)
 	}
@@ -331,7 +306,7 @@ func (r *userRepository) SearchUsers(ctx context.Context, query string, filters
 
 func (r *userRepository) GetVenueUserOwn(ctx context.Context, userID uuid.UUID) ([]models.VenueUserOwn, error) {
 	var venues []models.VenueUserOwn
-	err := r.db.SelectContext(ctx, &venues, `
+	err := conn(ctx, r.db).SelectContext(ctx, &venues, `
 		SELECT id FROM venues WHERE owner_id = $1`,
 		userID)
 
@@ -344,7 +319,7 @@ func (r *userRepository) GetVenueUserOwn(ctx context.Context, userID uuid.UUID)
 
 func (r *userRepository) IsUserExist(ctx context.Context, userID uuid.UUID) (bool, error) {
 	var count int
-	err := r.db.GetContext(ctx, &count, `
+	err := conn(ctx, r.db).GetContext(ctx, &count, `
 		SELECT COUNT(*) FROM users WHERE id = $1`,
 		userID)
 
@@ -35,7 +35,7 @@ func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error
     `
 
 	var exists bool
-	err := r.db.GetContext(ctx, &exists, checkQuery, venue.Name)
+	err := conn(ctx, r.db).GetContext(ctx, &exists, checkQuery, venue.Name)
 	if err != nil {
 		return fmt.Errorf("failed to check venue name: %w", err)
 	}
@@ -66,6 +66,9 @@ func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error
 		Facilities:    venue.Facilities,
 		Latitude:      venue.Latitude,
 		Longitude:     venue.Longitude,
+
+		BookingHoldMinutes: venue.BookingHoldMinutes,
+		CancellationPolicy: venue.CancellationPolicy.RawMessage,
 	}
 
 	// If no duplicate, proceed with insert
@@ -73,17 +76,19 @@ func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error
         INSERT INTO venues (
             id, name, description, address, location, phone, email,
             open_range, image_urls, status, rating,
-            total_reviews, owner_id, created_at, updated_at, rules, latitude, longitude
+            total_reviews, owner_id, created_at, updated_at, rules, latitude, longitude,
+            booking_hold_minutes, cancellation_policy
         ) VALUES (
             safe_generate_uuid(), :name, :description, :address, :location, :phone, :email,
             :open_range, :image_urls, :status, :rating,
-            :total_reviews, :owner_id, :created_at, :updated_at, :rules, :latitude, :longitude
+            :total_reviews, :owner_id, :created_at, :updated_at, :rules, :latitude, :longitude,
+            :booking_hold_minutes, :cancellation_policy
         )
         RETURNING *
     `
 
 	// Use NamedQueryRow instead of NamedExec to get the returned values
-	rows, err := r.db.NamedQueryContext(ctx, insertQuery, venueInsert)
+	rows, err := sqlx.NamedQueryContext(ctx, conn(ctx, r.db), insertQuery, venueInsert)
 	if err != nil {
 		return fmt.Errorf("failed to create venue: %w", err)
 	}
@@ -121,7 +126,7 @@ func (r *venueRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Ve
 	// Get venue details
 	query := `
 		SELECT * FROM venues  WHERE id = $1 AND deleted_at IS NULL`
-	err := r.db.GetContext(ctx, &result.Venue, query, id)
+	err := conn(ctx, r.db).GetContext(ctx, &result.Venue, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("venue not found")
@@ -136,7 +141,7 @@ func (r *venueRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Ve
 		JOIN facilities f ON vf.facility_id = f.id
 		WHERE venue_id = $1`
 
-	err = r.db.SelectContext(ctx, &result.Venue.Facilities, facilitiesQuery, id)
+	err = conn(ctx, r.db).SelectContext(ctx, &result.Venue.Facilities, facilitiesQuery, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get facilities: %w", err)
 	}
@@ -145,7 +150,7 @@ func (r *venueRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Ve
 		SELECT * FROM courts 
 		WHERE venue_id = $1 AND deleted_at IS NULL 
 		ORDER BY created_at`
-	err = r.db.SelectContext(ctx, &result.Courts, courtsQuery, id)
+	err = conn(ctx, r.db).SelectContext(ctx, &result.Courts, courtsQuery, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get courts: %w", err)
 	}
@@ -170,6 +175,9 @@ func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error
 		"rules":       venue.Rules.RawMessage,
 		"latitude":    venue.Latitude,
 		"longitude":   venue.Longitude,
+
+		"booking_hold_minutes": venue.BookingHoldMinutes,
+		"cancellation_policy":  venue.CancellationPolicy,
 	}
 
 	query := `
@@ -186,10 +194,12 @@ func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error
 			updated_at = :updated_at,
 			rules = :rules,
 			latitude = :latitude,
-			longitude = :longitude
+			longitude = :longitude,
+			booking_hold_minutes = :booking_hold_minutes,
+			cancellation_policy = :cancellation_policy
 		WHERE id = :id AND deleted_at IS NULL`
 
-	result, err := r.db.NamedExecContext(ctx, query, params)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, params)
 	if err != nil {
 		return fmt.Errorf("failed to update venue: %w", err)
 	}
@@ -212,7 +222,7 @@ func (r *venueRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		SET deleted_at = NOW(), updated_at = NOW() 
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete venue: %w", err)
 	}
@@ -229,12 +239,15 @@ func (r *venueRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *venueRepository) List(ctx context.Context, location string, limit, offset int) ([]models.Venue, error) {
+func (r *venueRepository) List(ctx context.Context, location string, geo *models.GeoQuery, limit, offset int) ([]models.Venue, error) {
+	distance, geoConditions, geoArgs := geoSearch(geo, 4)
+
 	query := `
 		SELECT 
 			v.id, v.name, v.description, v.address, v.location, v.phone, v.email,
 			v.open_range, v.image_urls, v.status, v.rating, v.total_reviews, v.owner_id,
-			v.created_at, v.updated_at, v.search_vector, v.rules,
+			v.created_at, v.updated_at, v.search_vector, v.rules, v.latitude, v.longitude,
+			` + distance + ` AS distance_km,
 			COALESCE(json_agg(
 				json_build_object('id', f.id, 'name', f.name)
 			) FILTER (WHERE f.id IS NOT NULL), '[]') AS facilities,
@@ -251,14 +264,15 @@ func (r *venueRepository) List(ctx context.Context, location string, limit, offs
 			courts c ON v.id = c.venue_id
 		WHERE 
 			v.deleted_at IS NULL
-			AND ($1 = '' OR v.location = $1)
+			AND ($1 = '' OR v.location = $1)` + geoConditions + `
 		GROUP BY 
 			v.id
 		ORDER BY 
-			v.rating DESC, v.total_reviews DESC, v.created_at DESC
+			distance_km ASC NULLS LAST, v.rating DESC, v.total_reviews DESC, v.created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, location, limit, offset)
+	params := append([]interface{}{location, limit, offset}, geoArgs...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, params...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list venues: %w", err)
 	}
@@ -275,6 +289,7 @@ func (r *venueRepository) List(ctx context.Context, location string, limit, offs
 			&venue.Phone, &venue.Email, &venue.OpenRange, &venue.ImageURLs,
 			&venue.Status, &venue.Rating, &venue.TotalReviews, &venue.OwnerID,
 			&venue.CreatedAt, &venue.UpdatedAt, &venue.Search_vector, &venue.Rules,
+			&venue.Latitude, &venue.Longitude, &venue.DistanceKm,
 			&facilitiesJSON, &courtsJSON,
 		)
 		if err != nil {
@@ -309,7 +324,7 @@ func (r *venueRepository) CountVenues(ctx context.Context) (int, error) {
 		WHERE deleted_at IS NULL
 `
 	var count int
-	err := r.db.GetContext(ctx, &count, query)
+	err := conn(ctx, r.db).GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count venues: %w", err)
 	}
@@ -317,12 +332,15 @@ func (r *venueRepository) CountVenues(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-func (r *venueRepository) Search(ctx context.Context, query string, limit, offset int, minPrice, maxPrice int, location string, facilities []string) ([]models.Venue, error) {
+func (r *venueRepository) Search(ctx context.Context, query string, limit, offset int, minPrice, maxPrice int, location string, facilities []string, geo *models.GeoQuery) ([]models.Venue, error) {
+	distance, geoConditions, geoArgs := geoSearch(geo, 7+len(facilities))
+
 	searchQuery := `
 		SELECT 
 			v.id, v.name, v.description, v.address, v.location, v.phone, v.email,
 			v.open_range, v.image_urls, v.status, v.rating, v.total_reviews, v.owner_id,
 			v.created_at, v.updated_at, v.rules, v.latitude, v.longitude,
+			` + distance + ` AS distance_km,
 			COALESCE(json_agg(
 				json_build_object('id', f.id, 'name', f.name)
 			) FILTER (WHERE f.id IS NOT NULL), '[]') AS facilities,
@@ -359,13 +377,14 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 		)
 		searchQuery += " " + facilitiesCondition
 	}
+	searchQuery += geoConditions
 
-	// Close the query with GROUP BY and ORDER BY clauses
+	// Close the query with GROUP BY and ORDER BY clauses, nearest first when searching around a point
 	searchQuery += `
 		GROUP BY 
 			v.id
 		ORDER BY 
-			v.rating DESC, v.total_reviews DESC, v.created_at DESC
+			distance_km ASC NULLS LAST, v.rating DESC, v.total_reviews DESC, v.created_at DESC
 		LIMIT $5 OFFSET $6`
 
 	// Prepare parameters, including facilities
@@ -373,9 +392,10 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 	for _, facility := range facilities {
 		params = append(params, facility)
 	}
+	params = append(params, geoArgs...)
 
 	// Execute the query
-	rows, err := r.db.QueryContext(ctx, searchQuery, params...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, searchQuery, params...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search venues: %w", err)
 	}
@@ -393,7 +413,7 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 			&venue.Phone, &venue.Email, &venue.OpenRange, &venue.ImageURLs,
 			&venue.Status, &venue.Rating, &venue.TotalReviews, &venue.OwnerID,
 			&venue.CreatedAt, &venue.UpdatedAt, &venue.Rules, &venue.Latitude, &venue.Longitude,
-			&facilitiesJSON, &courtsJSON,
+			&venue.DistanceKm, &facilitiesJSON, &courtsJSON,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan venue: %w", err)
@@ -421,7 +441,9 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 	return venues, nil
 }
 
-func (r *venueRepository) CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string) (int, error) {
+func (r *venueRepository) CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string, geo *models.GeoQuery) (int, error) {
+	_, geoConditions, geoArgs := geoSearch(geo, 5+len(facilities))
+
 	countQuery := `
 		SELECT 
 			COUNT(DISTINCT v.id)
@@ -455,16 +477,18 @@ func (r *venueRepository) CountSearch(ctx context.Context, query string, minPric
 		)
 		countQuery += " " + facilitiesCondition
 	}
+	countQuery += geoConditions
 
 	// Prepare parameters, including facilities
 	params := []interface{}{query, location, minPrice, maxPrice}
 	for _, facility := range facilities {
 		params = append(params, facility)
 	}
+	params = append(params, geoArgs...)
 
 	// Execute the count query
 	var count int
-	err := r.db.QueryRowContext(ctx, countQuery, params...).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, params...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count venues: %w", err)
 	}
@@ -472,6 +496,46 @@ func (r *venueRepository) CountSearch(ctx context.Context, query string, minPric
 	return count, nil
 }
 
+// geoSearch builds the SQL of a geo query with its parameters numbered from next: the
+// distance column in kilometres, the conditions to add to the WHERE clause and their parameters
+func geoSearch(geo *models.GeoQuery, next int) (string, string, []interface{}) {
+	distance := "NULL::float8"
+	conditions := ""
+	args := []interface{}{}
+	if geo == nil || (!geo.HasPoint() && geo.Bounds == nil) {
+		return distance, conditions, args
+	}
+
+	// Venues that never set their location sit at 0, 0
+	conditions += `
+			AND NOT (v.latitude = 0 AND v.longitude = 0)`
+
+	if geo.HasPoint() {
+		point := fmt.Sprintf("ll_to_earth($%d, $%d)", next, next+1)
+		args = append(args, *geo.Latitude, *geo.Longitude)
+		next += 2
+
+		distance = fmt.Sprintf("earth_distance(%s, ll_to_earth(v.latitude, v.longitude)) / 1000", point)
+
+		// earth_box finds the candidates through the GiST index, earth_distance trims the box to a circle
+		if geo.RadiusKm > 0 {
+			conditions += fmt.Sprintf(`
+			AND earth_box(%s, $%d) @> ll_to_earth(v.latitude, v.longitude)
+			AND %s <= $%d`, point, next, distance, next+1)
+			args = append(args, geo.RadiusKm*1000, geo.RadiusKm)
+			next += 2
+		}
+	}
+
+	if b := geo.Bounds; b != nil {
+		conditions += fmt.Sprintf(`
+			AND v.latitude BETWEEN $%d AND $%d
+			AND v.longitude BETWEEN $%d AND $%d`, next, next+1, next+2, next+3)
+		args = append(args, b.MinLatitude, b.MaxLatitude, b.MinLongitude, b.MaxLongitude)
+	}
+
+	return distance, conditions, args
+}
 
 func (r *venueRepository) AddCourt(ctx context.Context, court *models.Court) error {
 	query := `
@@ -483,7 +547,7 @@ func (r *venueRepository) AddCourt(ctx context.Context, court *models.Court) err
 			:status, :created_at, :updated_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, court)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, court)
 	if err != nil {
 		return fmt.Errorf("failed to add court: %w", err)
 	}
@@ -501,7 +565,7 @@ func (r *venueRepository) UpdateCourt(ctx context.Context, court *models.Court)
 			updated_at = :updated_at
 		WHERE id = :id AND deleted_at IS NULL`
 
-	result, err := r.db.NamedExecContext(ctx, query, court)
+	result, err := conn(ctx, r.db).NamedExecContext(ctx, query, court)
 	if err != nil {
 		return fmt.Errorf("failed to update court: %w", err)
 	}
@@ -524,7 +588,7 @@ func (r *venueRepository) DeleteCourt(ctx context.Context, id uuid.UUID) error {
 		SET deleted_at = NOW(), updated_at = NOW() 
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete court: %w", err)
 	}
@@ -548,7 +612,7 @@ func (r *venueRepository) GetCourts(ctx context.Context, venueID uuid.UUID) ([]m
 		ORDER BY created_at`
 
 	courts := []models.Court{}
-	err := r.db.SelectContext(ctx, &courts, query, venueID)
+	err := conn(ctx, r.db).SelectContext(ctx, &courts, query, venueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get courts: %w", err)
 	}
@@ -566,7 +630,7 @@ func (r *venueRepository) AddReview(ctx context.Context, review *models.VenueRev
 			:id, :venue_id, :user_id, :rating, :comment, :created_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, review)
+	_, err := conn(ctx, r.db).NamedExecContext(ctx, query, review)
 	if err != nil {
 		return fmt.Errorf("failed to add review: %w", err)
 	}
@@ -593,7 +657,7 @@ func (r *venueRepository) GetReviews(ctx context.Context, venueID uuid.UUID, lim
 		LIMIT $2 OFFSET $3`
 
 	reviews := []models.VenueReview{}
-	err := r.db.SelectContext(ctx, &reviews, query, venueID, limit, offset)
+	err := conn(ctx, r.db).SelectContext(ctx, &reviews, query, venueID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviews: %w", err)
 	}
@@ -618,7 +682,7 @@ func (r *venueRepository) UpdateVenueRating(ctx context.Context, venueID uuid.UU
 			updated_at = NOW()
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, venueID)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, venueID)
 	if err != nil {
 		return fmt.Errorf("failed to update venue rating: %w", err)
 	}
@@ -647,7 +711,7 @@ func (r *venueRepository) GetFacilities(ctx context.Context, venueID uuid.UUID)
 			venue_id = $1;`
 
 	facilities := []models.Facility{}
-	err := r.db.SelectContext(ctx, &facilities, query, venueID)
+	err := conn(ctx, r.db).SelectContext(ctx, &facilities, query, venueID)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get facilities: %w", err)
@@ -662,7 +726,7 @@ func (r *venueRepository) AddFacilities(ctx context.Context, venueID uuid.UUID,
 		VALUES (:venue_id, :facility_id)`
 
 	for i := range facilityIDs {
-		_, err := r.db.NamedExecContext(ctx, query, map[string]interface{}{
+		_, err := conn(ctx, r.db).NamedExecContext(ctx, query, map[string]interface{}{
 			"venue_id":    venueID,
 			"facility_id": facilityIDs[i],
 		})
@@ -679,7 +743,7 @@ func (r *venueRepository) UpdateFacilities(ctx context.Context, venueID uuid.UUI
 	deleteQuery := `
 		DELETE FROM venues_facilities
 		WHERE venue_id = $1`
-	_, err := r.db.ExecContext(ctx, deleteQuery, venueID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, deleteQuery, venueID)
 	if err != nil {
 		return fmt.Errorf("failed to update facilities: %w", err)
 	}
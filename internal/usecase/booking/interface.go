@@ -3,25 +3,43 @@ package booking
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
 
 	"github.com/google/uuid"
 )
 
 type UseCase interface {
 	CreateBooking(ctx context.Context, userID uuid.UUID, req requests.CreateBookingRequest) (*responses.BookingResponse, error)
+	CreateRecurringBooking(ctx context.Context, userID uuid.UUID, req requests.CreateRecurringBookingRequest) (*responses.RecurringBookingResponse, error)
+	CreateBookingOrder(ctx context.Context, userID uuid.UUID, req requests.CreateBookingOrderRequest) (*responses.BookingOrderResponse, error)
 	GetBooking(ctx context.Context, id uuid.UUID) (*responses.BookingResponse, error)
 	ListBookings(ctx context.Context, userID uuid.UUID, req requests.ListBookingsRequest) (*responses.BookingListResponse, error)
-	UpdateBooking(ctx context.Context, id uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error)
-	CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	// UpdateBooking changes the notes of the user's own booking. Status changes go through
+	// CancelBooking and payments.
+	UpdateBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error)
+	CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*responses.CancelBookingResponse, error)
+	CancelBookingSeries(ctx context.Context, seriesID uuid.UUID, userID uuid.UUID) ([]responses.CancelBookingResponse, error)
+	// CancelSessionBookings cancels the bookings of a cancelled session and refunds their payments in full
+	CancelSessionBookings(ctx context.Context, sessionID uuid.UUID) error
 	GetUserBookings(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.BookingResponse, error)
 	CheckAvailability(ctx context.Context, req requests.CheckAvailabilityRequest) (*responses.CourtAvailabilityResponse, error)
+	// GetVenueAvailability builds the half-hour slot grid of every court of a venue for up to a week
+	GetVenueAvailability(ctx context.Context, req requests.VenueAvailabilityRequest) (*responses.VenueAvailabilityResponse, error)
+	// SearchAvailability finds the venues with a court free at a time, optionally near a point
+	SearchAvailability(ctx context.Context, req requests.SearchAvailabilityRequest) (*responses.AvailabilitySearchResponse, error)
 	GetPayment(ctx context.Context, id uuid.UUID) (*responses.PaymentResponse, error)
 	CreatePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.CreatePaymentRequest) (*responses.PaymentResponse, error)
+	CreateOrderPayment(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, req requests.CreatePaymentRequest) (*responses.OrderPaymentResponse, error)
 	UpdatePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdatePaymentRequest) (*responses.PaymentResponse, error)
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error
 	ChangeCourtStatus(ctx context.Context) error
+	ExpirePendingBookings(ctx context.Context) error
+	// SettleRefunds sends the refunds recorded as pending to the payment gateway
+	SettleRefunds(ctx context.Context) error
 }
 
 var (
@@ -29,10 +47,30 @@ var (
 
 	ErrValidation = errors.New("validation error")
 
-	ErrBookingConflict = errors.New("booking conflict")
+	ErrBookingConflict = interfaces.ErrBookingConflict
 
 	ErrPaymentRequired = errors.New("payment required")
 
+	ErrPaymentNotFound = errors.New("payment not found")
+
+	ErrInvalidWebhook = errors.New("invalid payment webhook")
+
+	ErrInvalidPromotion = errors.New("invalid promotion code")
+
 	ErrBookingNotFound = errors.New("booking not found") // Added this line
 
+	ErrVenueNotFound = errors.New("venue not found")
 )
+
+// ConflictsError rejects a recurring booking or order, listing every slot that could not be booked
+type ConflictsError struct {
+	Conflicts []responses.BookingDateConflict
+}
+
+func (e *ConflictsError) Error() string {
+	return fmt.Sprintf("%v: %d of the requested slots are not available", ErrBookingConflict, len(e.Conflicts))
+}
+
+func (e *ConflictsError) Unwrap() error {
+	return ErrBookingConflict
+}
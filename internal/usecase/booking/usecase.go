@@ -2,24 +2,41 @@ package booking
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
+	"errors"
 	"fmt"
-	"strings"
+	"log"
+	"math"
+	"sort"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
+	gateway "badbuddy/internal/infrastructure/payment"
 	"badbuddy/internal/repositories/interfaces"
 
 	"github.com/google/uuid"
 )
 
+// paymentCurrency is the currency every booking is priced in
+const paymentCurrency = "THB"
+
+// refundBatchSize bounds how many pending refunds are sent to the gateway in one run
+const refundBatchSize = 100
+
 type useCase struct {
-	bookingRepo interfaces.BookingRepository
-	courtRepo   interfaces.CourtRepository
-	venueRepo   interfaces.VenueRepository
-	userRepo    interfaces.UserRepository
+	bookingRepo     interfaces.BookingRepository
+	courtRepo       interfaces.CourtRepository
+	venueRepo       interfaces.VenueRepository
+	userRepo        interfaces.UserRepository
+	sessionRepo     interfaces.SessionRepository
+	pricingRepo     interfaces.PricingRepository
+	promotionRepo   interfaces.PromotionRepository
+	scheduleRepo    interfaces.ScheduleRepository
+	maintenanceRepo interfaces.MaintenanceRepository
+	txManager       interfaces.TransactionManager
+	paymentProvider gateway.Provider
 }
 
 func NewBookingUseCase(
@@ -27,12 +44,26 @@ func NewBookingUseCase(
 	courtRepo interfaces.CourtRepository,
 	venueRepo interfaces.VenueRepository,
 	userRepo interfaces.UserRepository,
+	sessionRepo interfaces.SessionRepository,
+	pricingRepo interfaces.PricingRepository,
+	promotionRepo interfaces.PromotionRepository,
+	scheduleRepo interfaces.ScheduleRepository,
+	maintenanceRepo interfaces.MaintenanceRepository,
+	txManager interfaces.TransactionManager,
+	paymentProvider gateway.Provider,
 ) UseCase {
 	return &useCase{
-		bookingRepo: bookingRepo,
-		courtRepo:   courtRepo,
-		venueRepo:   venueRepo,
-		userRepo:    userRepo,
+		bookingRepo:     bookingRepo,
+		courtRepo:       courtRepo,
+		venueRepo:       venueRepo,
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		pricingRepo:     pricingRepo,
+		promotionRepo:   promotionRepo,
+		scheduleRepo:    scheduleRepo,
+		maintenanceRepo: maintenanceRepo,
+		txManager:       txManager,
+		paymentProvider: paymentProvider,
 	}
 }
 
@@ -80,41 +111,79 @@ func (uc *useCase) CreateBooking(ctx context.Context, userID uuid.UUID, req requ
 		Status:    venue.Status,
 		OpenRange: venue.OpenRange,
 	}
-	if err := uc.isVenueOpenForBooking(venueDetails, date, startTime, endTime); err != nil {
-		return nil, err
-	}
-	// Check availability
-	available, err := uc.bookingRepo.CheckCourtAvailability(ctx, courtID, date, startTime, endTime)
+
+	// Price the slot segment by segment with the venue's pricing rules
+	totalAmount, _, err := uc.priceBooking(ctx, court.VenueID, courtID, court.PricePerHour, date, startTime, endTime)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check availability: %w", err)
-	}
-	if !available {
-		return nil, fmt.Errorf("court is not available for the selected time slot")
+		return nil, err
 	}
-	// Calculate duration and total amount
-	duration := endTime.Sub(startTime)
-	hours := duration.Hours()
-	totalAmount := hours * court.PricePerHour
 
-	// Create booking
+	// Create booking, holding the slot until the venue's payment window runs out
+	now := time.Now()
 	booking := &models.CourtBooking{
-		ID:          uuid.New(),
-		CourtID:     courtID,
-		UserID:      userID,
-		Date:        date,
-		StartTime:   startTime,
-		EndTime:     endTime,
-		TotalAmount: totalAmount,
-		Status:      models.BookingStatusPending,
-		Notes:       req.Notes,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:            uuid.New(),
+		CourtID:       courtID,
+		UserID:        userID,
+		Date:          date,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		TotalAmount:   totalAmount,
+		Status:        models.BookingStatusPending,
+		Notes:         req.Notes,
+		HoldExpiresAt: models.HoldExpiry(now, venue.BookingHoldMinutes),
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 	if err := booking.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid booking: %w", err)
 	}
-	if err := uc.bookingRepo.Create(ctx, booking); err != nil {
-		return nil, fmt.Errorf("failed to create booking: %w", err)
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Hold the court so maintenance or another booking cannot take the slot between the
+		// checks and the insert
+		if err := uc.courtRepo.LockCourts(ctx, []uuid.UUID{courtID}); err != nil {
+			return fmt.Errorf("failed to lock court: %w", err)
+		}
+
+		if err := uc.isVenueOpenForBooking(ctx, venueDetails, courtID, date, startTime, endTime); err != nil {
+			return err
+		}
+
+		available, err := uc.courtFree(ctx, courtID, date, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return fmt.Errorf("%w: court is not available for the selected time slot", ErrBookingConflict)
+		}
+
+		var redemption *models.PromotionRedemption
+		if req.PromoCode != nil {
+			var err error
+			if redemption, err = uc.redeemPromotion(ctx, *req.PromoCode, court.VenueID, booking); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.bookingRepo.Create(ctx, booking); err != nil {
+			return fmt.Errorf("failed to create booking: %w", err)
+		}
+
+		if redemption != nil {
+			if err := uc.promotionRepo.Redeem(ctx, redemption); err != nil {
+				return fmt.Errorf("failed to redeem promotion: %w", err)
+			}
+		}
+
+		history := models.NewBookingStatusHistory(booking.ID, "", booking.Status, models.BookingReasonCreated, &userID)
+		if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+			return fmt.Errorf("failed to record booking status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get complete booking details
@@ -126,6 +195,357 @@ func (uc *useCase) CreateBooking(ctx context.Context, userID uuid.UUID, req requ
 	return bookingDetail.ToResponse(), nil
 }
 
+// CreateRecurringBooking books a court on the same weekdays and times across a date range.
+// Every date is checked before anything is created. Conflicting dates abort the request
+// unless the caller allows a partial booking, in which case only the free dates are booked.
+func (uc *useCase) CreateRecurringBooking(ctx context.Context, userID uuid.UUID, req requests.CreateRecurringBookingRequest) (*responses.RecurringBookingResponse, error) {
+	courtID, err := uuid.Parse(req.CourtID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid court ID", ErrValidation)
+	}
+
+	weekdays, err := models.ParseWeekdays(req.Weekdays)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid start date format", ErrValidation)
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid end date format", ErrValidation)
+	}
+	startTime, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid start time format", ErrValidation)
+	}
+	endTime, err := time.Parse("15:04", req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid end time format", ErrValidation)
+	}
+
+	court, err := uc.courtRepo.GetCourtWithVenueByID(ctx, courtID)
+	if err != nil {
+		return nil, fmt.Errorf("court not found: %w", err)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("venue not found: %w", err)
+	}
+	if venue.Status != models.VenueStatusActive {
+		return nil, fmt.Errorf("venue is not active")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	now := time.Now()
+	series := &models.BookingSeries{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CourtID:   courtID,
+		Weekdays:  weekdays,
+		StartsOn:  startDate,
+		EndsOn:    endDate,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Notes:     req.Notes,
+		CreatedAt: now,
+	}
+	if err := series.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	venueDetails := &models.Venue{
+		ID:        venue.ID,
+		Name:      venue.Name,
+		Status:    venue.Status,
+		OpenRange: venue.OpenRange,
+	}
+
+	result := &responses.RecurringBookingResponse{
+		SeriesID:  series.ID.String(),
+		Bookings:  []responses.BookingResponse{},
+		Conflicts: []responses.BookingDateConflict{},
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Hold the court so no other booking can take a date between the checks and the inserts
+		if err := uc.courtRepo.LockCourts(ctx, []uuid.UUID{courtID}); err != nil {
+			return fmt.Errorf("failed to lock court: %w", err)
+		}
+
+		bookings := []*models.CourtBooking{}
+		for _, date := range series.Dates() {
+			amount, _, err := uc.priceBooking(ctx, court.VenueID, courtID, court.PricePerHour, date, startTime, endTime)
+			if err != nil {
+				return err
+			}
+
+			// Contract bookings are settled with the venue over the season, so they are not
+			// held for checkout and never expire unpaid
+			booking := &models.CourtBooking{
+				ID:          uuid.New(),
+				CourtID:     courtID,
+				UserID:      userID,
+				SeriesID:    &series.ID,
+				Date:        date,
+				StartTime:   startTime,
+				EndTime:     endTime,
+				TotalAmount: amount,
+				Status:      models.BookingStatusPending,
+				Notes:       req.Notes,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			if err := booking.Validate(); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrValidation, date.Format("2006-01-02"), err)
+			}
+
+			reason, err := uc.dateConflict(ctx, venueDetails, booking)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				result.Conflicts = append(result.Conflicts, responses.BookingDateConflict{
+					Date:   date.Format("2006-01-02"),
+					Reason: reason,
+				})
+				continue
+			}
+
+			bookings = append(bookings, booking)
+		}
+
+		if len(result.Conflicts) > 0 && (!req.AllowPartial || len(bookings) == 0) {
+			return &ConflictsError{Conflicts: result.Conflicts}
+		}
+
+		if err := uc.bookingRepo.CreateBookingSeries(ctx, series); err != nil {
+			return fmt.Errorf("failed to create booking series: %w", err)
+		}
+
+		for _, booking := range bookings {
+			if err := uc.bookingRepo.Create(ctx, booking); err != nil {
+				return fmt.Errorf("failed to create booking: %w", err)
+			}
+
+			history := models.NewBookingStatusHistory(booking.ID, "", booking.Status, models.BookingReasonCreated, &userID)
+			if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+				return fmt.Errorf("failed to record booking status: %w", err)
+			}
+
+			booking.CourtName = court.Name
+			booking.VenueName = court.VenueName
+			booking.VenueLocation = court.VenueLocation
+			booking.UserName = user.FirstName + " " + user.LastName
+
+			result.Bookings = append(result.Bookings, *booking.ToResponse())
+			result.TotalAmount += booking.TotalAmount
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateBookingOrder checks out several court slots at once. Every slot is checked before
+// anything is created, and a conflict on any of them rejects the whole order, so the user
+// never ends up holding only part of it.
+func (uc *useCase) CreateBookingOrder(ctx context.Context, userID uuid.UUID, req requests.CreateBookingOrderRequest) (*responses.BookingOrderResponse, error) {
+	if len(req.Items) == 0 || len(req.Items) > models.MaxBookingOrderItems {
+		return nil, fmt.Errorf("%w: an order needs between 1 and %d items", ErrValidation, models.MaxBookingOrderItems)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	now := time.Now()
+	order := &models.BookingOrder{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+
+	bookings := make([]*models.CourtBooking, 0, len(req.Items))
+	bookingVenues := make([]*models.Venue, 0, len(req.Items))
+	courtIDs := make([]uuid.UUID, 0, len(req.Items))
+	venues := make(map[uuid.UUID]*models.Venue)
+	for i, item := range req.Items {
+		courtID, err := uuid.Parse(item.CourtID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: item %d: invalid court ID", ErrValidation, i+1)
+		}
+		date, err := time.Parse("2006-01-02", item.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: item %d: invalid date format", ErrValidation, i+1)
+		}
+		startTime, err := time.Parse("15:04", item.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: item %d: invalid start time format", ErrValidation, i+1)
+		}
+		endTime, err := time.Parse("15:04", item.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: item %d: invalid end time format", ErrValidation, i+1)
+		}
+
+		court, err := uc.courtRepo.GetCourtWithVenueByID(ctx, courtID)
+		if err != nil {
+			return nil, fmt.Errorf("court not found: %w", err)
+		}
+
+		venue, ok := venues[court.VenueID]
+		if !ok {
+			details, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+			if err != nil {
+				return nil, fmt.Errorf("venue not found: %w", err)
+			}
+			if details.Status != models.VenueStatusActive {
+				return nil, fmt.Errorf("venue %s is not active", details.Name)
+			}
+
+			venue = &models.Venue{
+				ID:                 details.ID,
+				Name:               details.Name,
+				Status:             details.Status,
+				OpenRange:          details.OpenRange,
+				BookingHoldMinutes: details.BookingHoldMinutes,
+			}
+			venues[court.VenueID] = venue
+		}
+
+		amount, _, err := uc.priceBooking(ctx, court.VenueID, courtID, court.PricePerHour, date, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		booking := &models.CourtBooking{
+			ID:            uuid.New(),
+			CourtID:       courtID,
+			UserID:        userID,
+			OrderID:       &order.ID,
+			Date:          date,
+			StartTime:     startTime,
+			EndTime:       endTime,
+			TotalAmount:   amount,
+			Status:        models.BookingStatusPending,
+			Notes:         req.Notes,
+			HoldExpiresAt: models.HoldExpiry(now, venue.BookingHoldMinutes),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+			CourtName:     court.Name,
+			VenueName:     court.VenueName,
+			VenueLocation: court.VenueLocation,
+			UserName:      user.FirstName + " " + user.LastName,
+		}
+		if err := booking.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: item %d: %v", ErrValidation, i+1, err)
+		}
+
+		for _, other := range bookings {
+			if booking.IsOverlapping(other) {
+				return nil, fmt.Errorf("%w: item %d overlaps another item of the order", ErrValidation, i+1)
+			}
+		}
+
+		bookings = append(bookings, booking)
+		bookingVenues = append(bookingVenues, venue)
+		courtIDs = append(courtIDs, courtID)
+		order.TotalAmount += booking.TotalAmount
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Hold every court of the order so no other booking can slip in between the checks and the inserts
+		if err := uc.courtRepo.LockCourts(ctx, courtIDs); err != nil {
+			return fmt.Errorf("failed to lock courts: %w", err)
+		}
+
+		conflicts := []responses.BookingDateConflict{}
+		for i, booking := range bookings {
+			reason, err := uc.dateConflict(ctx, bookingVenues[i], booking)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				conflicts = append(conflicts, responses.BookingDateConflict{
+					CourtID:   booking.CourtID.String(),
+					Date:      booking.Date.Format("2006-01-02"),
+					StartTime: booking.StartTime.Format("15:04"),
+					EndTime:   booking.EndTime.Format("15:04"),
+					Reason:    reason,
+				})
+			}
+		}
+		if len(conflicts) > 0 {
+			return &ConflictsError{Conflicts: conflicts}
+		}
+
+		if err := uc.bookingRepo.CreateBookingOrder(ctx, order); err != nil {
+			return fmt.Errorf("failed to create booking order: %w", err)
+		}
+
+		for _, booking := range bookings {
+			if err := uc.bookingRepo.Create(ctx, booking); err != nil {
+				return fmt.Errorf("failed to create booking: %w", err)
+			}
+
+			history := models.NewBookingStatusHistory(booking.ID, "", booking.Status, models.BookingReasonCreated, &userID)
+			if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+				return fmt.Errorf("failed to record booking status: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &responses.BookingOrderResponse{
+		ID:          order.ID.String(),
+		Bookings:    make([]responses.BookingResponse, len(bookings)),
+		TotalAmount: order.TotalAmount,
+		CreatedAt:   order.CreatedAt.Format(time.RFC3339),
+	}
+	for i, booking := range bookings {
+		result.Bookings[i] = *booking.ToResponse()
+	}
+
+	return result, nil
+}
+
+// dateConflict explains why a booking's date cannot be booked, or returns an empty reason when it can
+func (uc *useCase) dateConflict(ctx context.Context, venue *models.Venue, booking *models.CourtBooking) (string, error) {
+	schedule, err := uc.daySchedule(ctx, venue, booking.CourtID, booking.Date)
+	if err != nil {
+		return "", err
+	}
+	if err := schedule.Check(booking.StartTime, booking.EndTime); err != nil {
+		return err.Error(), nil
+	}
+
+	available, err := uc.courtFree(ctx, booking.CourtID, booking.Date, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return "", err
+	}
+	if !available {
+		return "court is already booked for this time slot", nil
+	}
+
+	return "", nil
+}
+
 func (uc *useCase) GetBooking(ctx context.Context, id uuid.UUID) (*responses.BookingResponse, error) {
 	booking, err := uc.bookingRepo.GetByID(ctx, id)
 	if err != nil {
@@ -210,12 +630,16 @@ func (uc *useCase) ListBookings(ctx context.Context, userID uuid.UUID, req reque
 	}, nil
 }
 
-func (uc *useCase) UpdateBooking(ctx context.Context, id uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error) {
+func (uc *useCase) UpdateBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error) {
 	booking, err := uc.bookingRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("booking not found: %w", err)
 	}
 
+	if booking.UserID != userID {
+		return nil, fmt.Errorf("%w: only the booker can update this booking", ErrUnauthorized)
+	}
+
 	if booking.Status == models.BookingStatusCancelled {
 		return nil, fmt.Errorf("cannot update cancelled booking")
 	}
@@ -224,10 +648,6 @@ func (uc *useCase) UpdateBooking(ctx context.Context, id uuid.UUID, req requests
 		return nil, fmt.Errorf("cannot update confirm booking")
 	}
 
-	if req.Status != "" {
-		booking.Status = models.BookingStatus(req.Status)
-	}
-
 	if req.Notes != nil {
 		booking.Notes = req.Notes
 	}
@@ -241,41 +661,186 @@ func (uc *useCase) UpdateBooking(ctx context.Context, id uuid.UUID, req requests
 	return booking.ToResponse(), nil
 }
 
-func (uc *useCase) CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+func (uc *useCase) CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*responses.CancelBookingResponse, error) {
+	var result *responses.CancelBookingResponse
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = uc.cancelBooking(ctx, id, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.settleRefunds(ctx)
+	return result, nil
+}
+
+func (uc *useCase) cancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*responses.CancelBookingResponse, error) {
 	booking, err := uc.bookingRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("booking not found: %w", err)
+		return nil, fmt.Errorf("booking not found: %w", err)
 	}
 
 	user, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
 	if booking.UserID != userID && user.Role != string(models.UserRoleAdmin) {
-		return fmt.Errorf("unauthorized to cancel this booking")
+		return nil, fmt.Errorf("%w: not allowed to cancel this booking", ErrUnauthorized)
 	}
 
-	if !booking.CanBeCancelled() {
-		return fmt.Errorf("booking cannot be cancelled")
+	now := time.Now()
+	if !booking.CanBeCancelled(now) {
+		return nil, fmt.Errorf("booking cannot be cancelled")
 	}
 
 	if err := uc.bookingRepo.CancelBooking(ctx, id); err != nil {
-		return fmt.Errorf("failed to cancel booking: %w", err)
+		return nil, fmt.Errorf("failed to cancel booking: %w", err)
+	}
+
+	history := models.NewBookingStatusHistory(id, booking.Status, models.BookingStatusCancelled, models.BookingReasonCancelledByUser, &userID)
+	if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+		return nil, fmt.Errorf("failed to record booking status: %w", err)
+	}
+
+	// A booking made for a session frees the court from that session as well
+	if booking.SessionID != nil {
+		if err := uc.sessionRepo.RemoveSessionCourt(ctx, *booking.SessionID, booking.CourtID); err != nil {
+			return nil, fmt.Errorf("failed to release session court: %w", err)
+		}
 	}
 
-	// Handle payment refund if needed
+	// A promotion used on a booking that was never paid can be used again
+	if booking.PromotionID != nil && (booking.Payment == nil || booking.Payment.Status != models.PaymentStatusCompleted) {
+		if err := uc.promotionRepo.ReleaseRedemption(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to release promotion: %w", err)
+		}
+	}
+
+	result := &responses.CancelBookingResponse{
+		BookingID: id.String(),
+		Status:    string(models.BookingStatusCancelled),
+	}
+
+	// Refund paid bookings according to the venue's cancellation policy
 	if booking.Payment != nil && booking.Payment.Status == models.PaymentStatusCompleted {
-		payment := booking.Payment
-		payment.Status = models.PaymentStatusRefunded
-		payment.UpdatedAt = time.Now()
+		refund, err := uc.processRefund(ctx, booking, now)
+		if err != nil {
+			return nil, err
+		}
 
-		if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
-			return fmt.Errorf("failed to update payment status: %w", err)
+		result.RefundPercent = refund.RefundPercent
+		result.RefundAmount = refund.Amount
+		if refund.Amount > 0 {
+			result.RefundID = refund.ID.String()
 		}
 	}
 
-	return nil
+	return result, nil
+}
+
+// CancelBookingSeries cancels every booking of a recurring booking that has not started yet.
+// Each booking is refunded under the venue's cancellation policy as if cancelled on its own.
+func (uc *useCase) CancelBookingSeries(ctx context.Context, seriesID uuid.UUID, userID uuid.UUID) ([]responses.CancelBookingResponse, error) {
+	results := []responses.CancelBookingResponse{}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.bookingRepo.GetBookingSeries(ctx, seriesID); err != nil {
+			return fmt.Errorf("%w: %v", ErrBookingNotFound, err)
+		}
+
+		bookings, err := uc.bookingRepo.GetSeriesBookings(ctx, seriesID)
+		if err != nil {
+			return fmt.Errorf("failed to get series bookings: %w", err)
+		}
+
+		now := time.Now()
+		for _, booking := range bookings {
+			// Bookings that already started are left as they are
+			if !booking.CanBeCancelled(now) {
+				continue
+			}
+
+			result, err := uc.cancelBooking(ctx, booking.ID, userID)
+			if err != nil {
+				return err
+			}
+			results = append(results, *result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.settleRefunds(ctx)
+	return results, nil
+}
+
+// CancelSessionBookings cancels every booking made for a session when the host cancels it.
+// The players did not choose to cancel, so paid bookings are refunded in full regardless of
+// the venue's cancellation policy. It usually runs inside the caller's transaction, so the
+// refunds are left pending for the caller to settle once that commits.
+func (uc *useCase) CancelSessionBookings(ctx context.Context, sessionID uuid.UUID) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		bookings, err := uc.bookingRepo.GetSessionBookings(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get session bookings: %w", err)
+		}
+
+		now := time.Now()
+		for _, sessionBooking := range bookings {
+			booking, err := uc.bookingRepo.GetByID(ctx, sessionBooking.ID)
+			if err != nil {
+				return fmt.Errorf("booking not found: %w", err)
+			}
+
+			if err := uc.bookingRepo.CancelBooking(ctx, booking.ID); err != nil {
+				return fmt.Errorf("failed to cancel booking: %w", err)
+			}
+
+			history := models.NewBookingStatusHistory(booking.ID, booking.Status, models.BookingStatusCancelled, models.BookingReasonSessionCancelled, nil)
+			if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+				return fmt.Errorf("failed to record booking status: %w", err)
+			}
+
+			payment := booking.Payment
+			if payment == nil || payment.Status != models.PaymentStatusCompleted {
+				if booking.PromotionID != nil {
+					if err := uc.promotionRepo.ReleaseRedemption(ctx, booking.ID); err != nil {
+						return fmt.Errorf("failed to release promotion: %w", err)
+					}
+				}
+				continue
+			}
+
+			refund := &models.Refund{
+				ID:            uuid.New(),
+				BookingID:     booking.ID,
+				PaymentID:     payment.ID,
+				Amount:        payment.Amount,
+				RefundPercent: 100,
+				Reason:        models.BookingReasonSessionCancelled,
+				CreatedAt:     now,
+			}
+			if refund.Amount <= 0 {
+				payment.Status = models.PaymentStatusRefunded
+				payment.UpdatedAt = now
+				if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
+					return fmt.Errorf("failed to update payment status: %w", err)
+				}
+				continue
+			}
+
+			if err := uc.issueRefund(ctx, payment, refund); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 func (uc *useCase) GetUserBookings(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.BookingResponse, error) {
@@ -319,14 +884,41 @@ func (uc *useCase) CheckAvailability(ctx context.Context, req requests.CheckAvai
 		return nil, fmt.Errorf("court not found: %w", err)
 	}
 
-	// Check availability
-	available, err := uc.bookingRepo.CheckCourtAvailability(ctx, courtID, date, startTime, endTime)
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check availability: %w", err)
+		return nil, fmt.Errorf("venue not found: %w", err)
+	}
+
+	// Check availability
+	available, err := uc.courtFree(ctx, courtID, date, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := uc.pricingRepo.GetVenuePricing(ctx, court.VenueID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing rules: %w", err)
+	}
+	price, segments := pricing.Price(courtID, court.PricePerHour, date, startTime, endTime)
+
+	breakdown := make([]responses.PriceSegmentResponse, len(segments))
+	for i, segment := range segments {
+		breakdown[i] = segment.ToResponse()
+	}
+
+	schedule, err := uc.daySchedule(ctx, &venue.Venue, courtID, date)
+	if err != nil {
+		return nil, err
 	}
+	available = available && schedule.Check(startTime, endTime) == nil
 
-	// Get existing bookings for the day
-	bookings, err := uc.bookingRepo.GetCourtBookings(ctx, courtID, date)
+	timeSlots, err := uc.generateTimeSlots(ctx, &court.Court, date, schedule, pricing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the bookings and session reservations holding the court that day
+	bookings, err := uc.bookingRepo.GetCourtsBookedSlots(ctx, []uuid.UUID{courtID}, date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get court bookings: %w", err)
 	}
@@ -343,11 +935,399 @@ func (uc *useCase) CheckAvailability(ctx context.Context, req requests.CheckAvai
 	}
 
 	return &responses.CourtAvailabilityResponse{
-		CourtID:   courtID.String(),
-		CourtName: court.Name,
-		Date:      date.Format("2006-01-02"),
-		Available: available,
-		Conflicts: conflicts,
+		CourtID:        courtID.String(),
+		CourtName:      court.Name,
+		Date:           date.Format("2006-01-02"),
+		Available:      available,
+		TimeSlots:      timeSlots,
+		Conflicts:      conflicts,
+		Price:          price,
+		PriceBreakdown: breakdown,
+	}, nil
+}
+
+// maxAvailabilityDays is how many days a single availability grid can cover
+const maxAvailabilityDays = 7
+
+// GetVenueAvailability loads everything the grid needs for the whole venue and range up front,
+// so the number of queries does not grow with the number of courts or days
+func (uc *useCase) GetVenueAvailability(ctx context.Context, req requests.VenueAvailabilityRequest) (*responses.VenueAvailabilityResponse, error) {
+	venueID, err := uuid.Parse(req.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid venue ID", ErrValidation)
+	}
+
+	from, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date format", ErrValidation)
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = 1
+	}
+	if days < 1 || days > maxAvailabilityDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrValidation, maxAvailabilityDays)
+	}
+	to := from.AddDate(0, 0, days-1)
+
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	courts, err := uc.courtRepo.GetByVenue(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courts: %w", err)
+	}
+
+	bookings, err := uc.bookingRepo.GetVenueBookedSlots(ctx, venueID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	overrides, err := uc.scheduleRepo.GetRangeOverrides(ctx, venueID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule overrides: %w", err)
+	}
+
+	rangeStart := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, models.BookingTimeZone)
+	windows, err := uc.maintenanceRepo.GetVenueOverlapping(ctx, venueID, rangeStart, rangeStart.AddDate(0, 0, days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get court maintenance: %w", err)
+	}
+
+	rules, err := uc.pricingRepo.ListRules(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing rules: %w", err)
+	}
+
+	holidays, err := uc.pricingRepo.ListHolidays(ctx, venueID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	// Index everything by court and date so each slot is a lookup
+	dayOverrides := make(map[string][]models.ScheduleOverride)
+	for _, override := range overrides {
+		key := override.Date.Format("2006-01-02")
+		dayOverrides[key] = append(dayOverrides[key], override)
+	}
+
+	courtWindows := make(map[uuid.UUID][]models.MaintenanceWindow)
+	for _, window := range windows {
+		courtWindows[window.CourtID] = append(courtWindows[window.CourtID], window)
+	}
+
+	courtBookings := make(map[string][]models.CourtBooking)
+	for _, booking := range bookings {
+		key := booking.CourtID.String() + booking.Date.Format("2006-01-02")
+		courtBookings[key] = append(courtBookings[key], booking)
+	}
+
+	holidayDates := make(map[string]bool)
+	for _, holiday := range holidays {
+		holidayDates[holiday.Date.Format("2006-01-02")] = true
+	}
+
+	result := &responses.VenueAvailabilityResponse{
+		VenueID:   venueID.String(),
+		VenueName: venue.Name,
+		Days:      make([]responses.DayAvailabilityResponse, 0, days),
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format("2006-01-02")
+
+		// The venue's own hours set the span of the grid, court overrides can only narrow them
+		venueSchedule, err := models.WeeklySchedule(venue.OpenRange, date)
+		if err != nil {
+			return nil, err
+		}
+		venueSchedule.ApplyOverrides(dayOverrides[key], uuid.Nil)
+
+		day := responses.DayAvailabilityResponse{
+			Date:   key,
+			Open:   venueSchedule.Open && venueSchedule.OpenMinute < venueSchedule.CloseMinute,
+			Courts: make([]responses.CourtAvailabilityGridItem, 0, len(courts)),
+		}
+		if day.Open {
+			day.OpenTime, day.CloseTime = venueSchedule.Hours()
+		} else {
+			day.Reason = venueSchedule.Reason
+		}
+
+		pricing := &models.VenuePricing{Rules: rules, Holiday: holidayDates[key]}
+
+		for _, court := range courts {
+			schedule, err := models.WeeklySchedule(venue.OpenRange, date)
+			if err != nil {
+				return nil, err
+			}
+			schedule.ApplyOverrides(dayOverrides[key], court.ID)
+			schedule.ApplyMaintenance(date, courtWindows[court.ID])
+
+			row := responses.CourtAvailabilityGridItem{
+				CourtID:   court.ID.String(),
+				CourtName: court.Name,
+				Slots:     []responses.GridSlot{},
+			}
+
+			for _, slot := range venueSchedule.HalfHours() {
+				startTime, endTime := slot[0], slot[1]
+
+				status := schedule.SlotStatus(startTime, endTime)
+				if status == models.SlotStatusFree && bookedDuring(courtBookings[court.ID.String()+key], date, startTime, endTime) {
+					status = models.SlotStatusBooked
+				}
+
+				price, _ := pricing.Price(court.ID, court.PricePerHour, date, startTime, endTime)
+				row.Slots = append(row.Slots, responses.GridSlot{
+					StartTime: startTime.Format("15:04"),
+					EndTime:   endTime.Format("15:04"),
+					Status:    string(status),
+					Price:     price,
+				})
+			}
+
+			day.Courts = append(day.Courts, row)
+		}
+
+		result.Days = append(result.Days, day)
+	}
+
+	return result, nil
+}
+
+// defaultSearchLimit is how many venues an availability search returns unless asked
+// otherwise, and maxSearchLimit the most it returns at once
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+// SearchAvailability finds the venues with a court free for the searched time. The courts are
+// found with one query and their bookings, overrides, maintenance and pricing are loaded
+// together, so the number of queries does not grow with the number of venues.
+func (uc *useCase) SearchAvailability(ctx context.Context, req requests.SearchAvailabilityRequest) (*responses.AvailabilitySearchResponse, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date format", ErrValidation)
+	}
+
+	from, err := time.Parse("15:04", req.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from time format", ErrValidation)
+	}
+
+	to, err := time.Parse("15:04", req.To)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid to time format", ErrValidation)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrValidation)
+	}
+
+	duration := to.Sub(from)
+	if req.Duration > 0 {
+		duration = time.Duration(req.Duration) * time.Minute
+	}
+	if duration < 30*time.Minute || duration > 4*time.Hour || duration%(30*time.Minute) != 0 {
+		return nil, fmt.Errorf("%w: duration must be whole half hours between 30 minutes and 4 hours", ErrValidation)
+	}
+	if duration > to.Sub(from) {
+		return nil, fmt.Errorf("%w: duration does not fit between from and to", ErrValidation)
+	}
+
+	now := time.Now().In(models.BookingTimeZone)
+	if date.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return nil, fmt.Errorf("%w: date must not be in the past", ErrValidation)
+	}
+
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, fmt.Errorf("%w: lat and lng must be given together", ErrValidation)
+	}
+	if req.RadiusKm < 0 || req.RadiusKm > 100 {
+		return nil, fmt.Errorf("%w: radius_km must be between 0 and 100", ErrValidation)
+	}
+	if req.RadiusKm > 0 && req.Latitude == nil {
+		return nil, fmt.Errorf("%w: a radius needs lat and lng", ErrValidation)
+	}
+	if req.MaxPrice < 0 {
+		return nil, fmt.Errorf("%w: max_price must not be negative", ErrValidation)
+	}
+
+	sortBy := req.SortBy
+	if sortBy == "" {
+		sortBy = "price"
+		if req.Latitude != nil {
+			sortBy = "distance"
+		}
+	}
+	if sortBy != "distance" && sortBy != "price" {
+		return nil, fmt.Errorf("%w: sort_by must be distance or price", ErrValidation)
+	}
+	if sortBy == "distance" && req.Latitude == nil {
+		return nil, fmt.Errorf("%w: sorting by distance needs lat and lng", ErrValidation)
+	}
+
+	if req.Limit < 0 || req.Limit > maxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrValidation, maxSearchLimit)
+	}
+	if req.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrValidation)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	courts, err := uc.courtRepo.SearchCourts(ctx, req.Facilities, req.Latitude, req.Longitude, req.RadiusKm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search courts: %w", err)
+	}
+	if len(courts) == 0 {
+		return &responses.AvailabilitySearchResponse{Venues: []responses.AvailableVenueResponse{}}, nil
+	}
+
+	courtIDs := make([]uuid.UUID, len(courts))
+	venueIDs := []uuid.UUID{}
+	seenVenues := make(map[uuid.UUID]bool)
+	for i, court := range courts {
+		courtIDs[i] = court.ID
+		if !seenVenues[court.VenueID] {
+			seenVenues[court.VenueID] = true
+			venueIDs = append(venueIDs, court.VenueID)
+		}
+	}
+
+	bookings, err := uc.bookingRepo.GetCourtsBookedSlots(ctx, courtIDs, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	overrides, err := uc.scheduleRepo.GetVenuesDateOverrides(ctx, venueIDs, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule overrides: %w", err)
+	}
+
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, models.BookingTimeZone)
+	windows, err := uc.maintenanceRepo.GetCourtsOverlapping(ctx, courtIDs, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get court maintenance: %w", err)
+	}
+
+	pricing, err := uc.pricingRepo.GetVenuesPricing(ctx, venueIDs, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing rules: %w", err)
+	}
+
+	courtBookings := make(map[uuid.UUID][]models.CourtBooking)
+	for _, booking := range bookings {
+		courtBookings[booking.CourtID] = append(courtBookings[booking.CourtID], booking)
+	}
+
+	venueOverrides := make(map[uuid.UUID][]models.ScheduleOverride)
+	for _, override := range overrides {
+		venueOverrides[override.VenueID] = append(venueOverrides[override.VenueID], override)
+	}
+
+	courtWindows := make(map[uuid.UUID][]models.MaintenanceWindow)
+	for _, window := range windows {
+		courtWindows[window.CourtID] = append(courtWindows[window.CourtID], window)
+	}
+
+	venues := make(map[uuid.UUID]*responses.AvailableVenueResponse)
+	for _, court := range courts {
+		schedule, err := models.WeeklySchedule(court.VenueOpenRange, date)
+		if err != nil {
+			// A venue without usable opening hours cannot be booked, but should not fail the search
+			continue
+		}
+		schedule.ApplyOverrides(venueOverrides[court.VenueID], court.ID)
+		schedule.ApplyMaintenance(date, courtWindows[court.ID])
+
+		slots := []responses.TimeSlot{}
+		for startTime := from; !startTime.Add(duration).After(to); startTime = startTime.Add(30 * time.Minute) {
+			endTime := startTime.Add(duration)
+
+			start := time.Date(date.Year(), date.Month(), date.Day(), startTime.Hour(), startTime.Minute(), 0, 0, models.BookingTimeZone)
+			if start.Before(now) || schedule.Check(startTime, endTime) != nil || bookedDuring(courtBookings[court.ID], date, startTime, endTime) {
+				continue
+			}
+
+			price, _ := pricing[court.VenueID].Price(court.ID, court.PricePerHour, date, startTime, endTime)
+			if req.MaxPrice > 0 && price > req.MaxPrice {
+				continue
+			}
+
+			slots = append(slots, responses.TimeSlot{
+				StartTime: startTime.Format("15:04"),
+				EndTime:   endTime.Format("15:04"),
+				Price:     price,
+			})
+		}
+		if len(slots) == 0 {
+			continue
+		}
+
+		venue, ok := venues[court.VenueID]
+		if !ok {
+			venue = &responses.AvailableVenueResponse{
+				VenueID:     court.VenueID.String(),
+				VenueName:   court.VenueName,
+				Address:     court.VenueAddress,
+				Location:    court.VenueLocation,
+				Latitude:    court.VenueLatitude,
+				Longitude:   court.VenueLongitude,
+				Rating:      court.VenueRating,
+				DistanceKm:  court.DistanceKm,
+				LowestPrice: slots[0].Price,
+				Courts:      []responses.AvailableCourtResponse{},
+			}
+			venues[court.VenueID] = venue
+		}
+
+		for _, slot := range slots {
+			venue.LowestPrice = math.Min(venue.LowestPrice, slot.Price)
+		}
+
+		venue.Courts = append(venue.Courts, responses.AvailableCourtResponse{
+			CourtID:   court.ID.String(),
+			CourtName: court.Name,
+			Slots:     slots,
+		})
+	}
+
+	results := make([]responses.AvailableVenueResponse, 0, len(venues))
+	for _, id := range venueIDs {
+		if venue, ok := venues[id]; ok {
+			results = append(results, *venue)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if sortBy == "distance" && *a.DistanceKm != *b.DistanceKm {
+			return *a.DistanceKm < *b.DistanceKm
+		}
+		if a.LowestPrice != b.LowestPrice {
+			return a.LowestPrice < b.LowestPrice
+		}
+		return a.Rating > b.Rating
+	})
+
+	total := len(results)
+	start := min(req.Offset, total)
+	end := min(start+limit, total)
+
+	return &responses.AvailabilitySearchResponse{
+		Venues: results[start:end],
+		Total:  total,
 	}, nil
 }
 
@@ -357,15 +1337,7 @@ func (uc *useCase) GetPayment(ctx context.Context, id uuid.UUID) (*responses.Pay
 		return nil, fmt.Errorf("payment not found: %w", err)
 	}
 
-	return &responses.PaymentResponse{
-		ID:            payment.ID.String(),
-		Amount:        payment.Amount,
-		Status:        string(payment.Status),
-		PaymentMethod: string(payment.PaymentMethod),
-		TransactionID: *payment.TransactionID,
-		CreatedAt:     payment.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     payment.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return payment.ToResponse(), nil
 }
 
 func (uc *useCase) CreatePayment(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID, req requests.CreatePaymentRequest) (*responses.PaymentResponse, error) {
@@ -374,96 +1346,424 @@ func (uc *useCase) CreatePayment(ctx context.Context, bookingID uuid.UUID, userI
 		return nil, fmt.Errorf("booking not found: %w", err)
 	}
 
+	if booking.UserID != userID {
+		return nil, fmt.Errorf("%w: only the booker can pay for this booking", ErrUnauthorized)
+	}
+
+	if booking.OrderID != nil {
+		return nil, fmt.Errorf("%w: booking is part of an order, pay for the order instead", ErrValidation)
+	}
+
 	if booking.Status != models.BookingStatusPending {
 		return nil, fmt.Errorf("booking is not in pending state")
 	}
 
-	if booking.Payment != nil {
+	// A failed attempt may be retried, anything else means the booking is already being paid
+	if booking.Payment != nil && booking.Payment.Status != models.PaymentStatusFailed {
 		return nil, fmt.Errorf("payment already exists for this booking")
 	}
 
-	if req.Amount != booking.TotalAmount {
-		return nil, fmt.Errorf("payment amount does not match booking amount")
+	if req.PromoCode != nil && booking.PromotionID != nil {
+		return nil, fmt.Errorf("%w: a promotion has already been applied to this booking", ErrValidation)
 	}
 
+	now := time.Now()
 	payment := &models.Payment{
 		ID:            uuid.New(),
 		BookingID:     bookingID,
 		UserID:        userID,
-		Amount:        req.Amount,
 		Status:        models.PaymentStatusPending,
 		PaymentMethod: models.PaymentMethod(req.PaymentMethod),
-		TransactionID: req.TransactionID,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if booking.Payment != nil {
+		payment.ID = booking.Payment.ID
+		payment.CreatedAt = booking.Payment.CreatedAt
 	}
 
-	if err := uc.bookingRepo.CreatePayment(ctx, payment); err != nil {
-		return nil, fmt.Errorf("failed to create payment: %w", err)
+	// The discount and the payment are committed together, so a failed payment never uses up the code
+	var checkoutURL string
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if req.PromoCode != nil {
+			if err := uc.applyPromotion(ctx, *req.PromoCode, booking, now); err != nil {
+				return err
+			}
+		}
+
+		if math.Round(req.Amount*100) != math.Round(booking.TotalAmount*100) {
+			return fmt.Errorf("payment amount does not match booking amount")
+		}
+		payment.Amount = booking.TotalAmount
+
+		// Card and QR payments are settled by the gateway, which reports back through the webhook.
+		// The client's own transaction reference is never trusted for them.
+		if payment.PaymentMethod.UsesGateway() {
+			intent, err := uc.paymentProvider.CreateIntent(ctx, gateway.IntentRequest{
+				Reference: bookingID.String(),
+				Amount:    payment.Amount,
+				Currency:  paymentCurrency,
+				Method:    gateway.Method(payment.PaymentMethod),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create payment intent: %w", err)
+			}
+
+			payment.TransactionID = &intent.ID
+			checkoutURL = intent.CheckoutURL
+		} else {
+			payment.TransactionID = req.TransactionID
+		}
+
+		var err error
+		if booking.Payment != nil {
+			err = uc.bookingRepo.UpdatePayment(ctx, payment)
+		} else {
+			err = uc.bookingRepo.CreatePayment(ctx, payment)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Update booking status if payment method is not Cash
-	if payment.PaymentMethod != models.PaymentMethodCash {
-		booking.Status = models.BookingStatusConfirmed
-		booking.UpdatedAt = time.Now()
+	resp := payment.ToResponse()
+	resp.CheckoutURL = checkoutURL
+
+	return resp, nil
+}
 
-		if err := uc.bookingRepo.Update(ctx, booking); err != nil {
-			return nil, fmt.Errorf("failed to update booking status: %w", err)
+// applyPromotion redeems a promotion code on an existing booking and stores its reduced total
+func (uc *useCase) applyPromotion(ctx context.Context, code string, booking *models.CourtBooking, now time.Time) error {
+	court, err := uc.courtRepo.GetByID(ctx, booking.CourtID)
+	if err != nil {
+		return fmt.Errorf("court not found: %w", err)
+	}
+
+	redemption, err := uc.redeemPromotion(ctx, code, court.VenueID, booking)
+	if err != nil {
+		return err
+	}
+
+	booking.UpdatedAt = now
+	if err := uc.bookingRepo.ApplyPromotion(ctx, booking); err != nil {
+		return fmt.Errorf("failed to apply promotion: %w", err)
+	}
+
+	if err := uc.promotionRepo.Redeem(ctx, redemption); err != nil {
+		return fmt.Errorf("failed to redeem promotion: %w", err)
+	}
+
+	return nil
+}
+
+// redeemPromotion checks a promotion code against a booking and the code's usage caps, then
+// takes the discount off the booking's total. It must run inside a transaction: the promotion
+// stays locked until it commits, so concurrent redemptions cannot go past the caps. The
+// returned redemption is stored by the caller once the booking exists.
+func (uc *useCase) redeemPromotion(ctx context.Context, code string, venueID uuid.UUID, booking *models.CourtBooking) (*models.PromotionRedemption, error) {
+	promotion, err := uc.promotionRepo.LockByCode(ctx, models.NormalizePromotionCode(code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: code %q does not exist", ErrInvalidPromotion, code)
 		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
 	}
-	return &responses.PaymentResponse{
-		ID:            payment.ID.String(),
-		Amount:        payment.Amount,
-		Status:        string(payment.Status),
-		PaymentMethod: string(payment.PaymentMethod),
-		TransactionID: *payment.TransactionID,
-		CreatedAt:     payment.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     payment.UpdatedAt.Format(time.RFC3339),
+
+	now := time.Now()
+	if err := promotion.CheckApplicable(now, venueID, booking.CourtID, booking.TotalAmount); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPromotion, err)
+	}
+
+	if promotion.MaxUses != nil && promotion.UsesCount >= *promotion.MaxUses {
+		return nil, fmt.Errorf("%w: promotion has been fully redeemed", ErrInvalidPromotion)
+	}
+
+	if promotion.MaxUsesPerUser != nil {
+		used, err := uc.promotionRepo.CountUserRedemptions(ctx, promotion.ID, booking.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count promotion uses: %w", err)
+		}
+		if used >= *promotion.MaxUsesPerUser {
+			return nil, fmt.Errorf("%w: promotion has already been used the maximum number of times", ErrInvalidPromotion)
+		}
+	}
+
+	discount := promotion.Discount(booking.TotalAmount)
+	booking.PromotionID = &promotion.ID
+	booking.DiscountAmount = discount
+	booking.TotalAmount = math.Round((booking.TotalAmount-discount)*100) / 100
+
+	return &models.PromotionRedemption{
+		ID:             uuid.New(),
+		PromotionID:    promotion.ID,
+		UserID:         booking.UserID,
+		BookingID:      booking.ID,
+		DiscountAmount: discount,
+		CreatedAt:      now,
 	}, nil
 }
 
+// CreateOrderPayment starts a single payment covering every booking of an order. It is
+// recorded as a payment per booking for that booking's share, all under one transaction.
+func (uc *useCase) CreateOrderPayment(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, req requests.CreatePaymentRequest) (*responses.OrderPaymentResponse, error) {
+	order, err := uc.bookingRepo.GetBookingOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBookingNotFound, err)
+	}
+
+	if order.UserID != userID {
+		return nil, fmt.Errorf("%w: only the booker can pay for this order", ErrUnauthorized)
+	}
+
+	if req.PromoCode != nil {
+		return nil, fmt.Errorf("%w: promotion codes apply to single bookings, not orders", ErrValidation)
+	}
+
+	if math.Round(req.Amount*100) != math.Round(order.TotalAmount*100) {
+		return nil, fmt.Errorf("%w: payment amount does not match order amount", ErrValidation)
+	}
+
+	bookings, err := uc.bookingRepo.GetOrderBookings(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order bookings: %w", err)
+	}
+
+	for _, booking := range bookings {
+		if booking.Status != models.BookingStatusPending {
+			return nil, fmt.Errorf("%w: booking %s is not in pending state", ErrValidation, booking.ID)
+		}
+
+		// A failed attempt may be retried, anything else means the order is already being paid
+		if booking.Payment != nil && booking.Payment.Status != models.PaymentStatusFailed {
+			return nil, fmt.Errorf("payment already exists for this order")
+		}
+	}
+
+	method := models.PaymentMethod(req.PaymentMethod)
+	transactionID := req.TransactionID
+	var checkoutURL string
+	if method.UsesGateway() {
+		intent, err := uc.paymentProvider.CreateIntent(ctx, gateway.IntentRequest{
+			Reference: orderID.String(),
+			Amount:    order.TotalAmount,
+			Currency:  paymentCurrency,
+			Method:    gateway.Method(method),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create payment intent: %w", err)
+		}
+
+		transactionID = &intent.ID
+		checkoutURL = intent.CheckoutURL
+	}
+
+	result := &responses.OrderPaymentResponse{
+		OrderID:       orderID.String(),
+		Amount:        order.TotalAmount,
+		Status:        string(models.PaymentStatusPending),
+		PaymentMethod: string(method),
+		CheckoutURL:   checkoutURL,
+		Payments:      make([]responses.PaymentResponse, len(bookings)),
+	}
+	if transactionID != nil {
+		result.TransactionID = *transactionID
+	}
+
+	now := time.Now()
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, booking := range bookings {
+			payment := &models.Payment{
+				ID:            uuid.New(),
+				BookingID:     booking.ID,
+				OrderID:       &order.ID,
+				UserID:        userID,
+				Amount:        booking.TotalAmount,
+				Status:        models.PaymentStatusPending,
+				PaymentMethod: method,
+				TransactionID: transactionID,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+
+			var err error
+			if booking.Payment != nil {
+				payment.ID = booking.Payment.ID
+				payment.CreatedAt = booking.Payment.CreatedAt
+				err = uc.bookingRepo.UpdatePayment(ctx, payment)
+			} else {
+				err = uc.bookingRepo.CreatePayment(ctx, payment)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create payment: %w", err)
+			}
+
+			result.Payments[i] = *payment.ToResponse()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdatePayment lets venue staff record the outcome of a cash or transfer payment by hand
 func (uc *useCase) UpdatePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdatePaymentRequest) (*responses.PaymentResponse, error) {
 	payment, err := uc.bookingRepo.GetPayment(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("payment not found: %w", err)
 	}
 
-	if payment.UserID != userID { // check if user is admin will pass this check
-		return nil, fmt.Errorf("unauthorized to update this payment")
+	if payment.PaymentMethod.UsesGateway() {
+		return nil, fmt.Errorf("%w: %s payments are confirmed by the payment gateway", ErrValidation, payment.PaymentMethod)
+	}
+
+	if err := uc.authorizeVenueStaff(ctx, payment.BookingID, userID); err != nil {
+		return nil, err
 	}
 
 	if payment.Status != models.PaymentStatusPending {
 		return nil, fmt.Errorf("payment already completed")
 	}
 
-	if req.Status != "" {
-		payment.Status = models.PaymentStatus(req.Status)
+	status := models.PaymentStatus(req.Status)
+	if status != models.PaymentStatusCompleted && status != models.PaymentStatusFailed {
+		return nil, fmt.Errorf("%w: status must be %s or %s", ErrValidation, models.PaymentStatusCompleted, models.PaymentStatusFailed)
+	}
+
+	payment.Status = status
+	if req.PaymentMethod != "" {
+		method := models.PaymentMethod(req.PaymentMethod)
+		if method.UsesGateway() {
+			return nil, fmt.Errorf("%w: cannot switch to %s after the payment was created", ErrValidation, method)
+		}
+		payment.PaymentMethod = method
 	}
 
 	payment.UpdatedAt = time.Now()
 
-	if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
-		return nil, fmt.Errorf("failed to update payment: %w", err)
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// The payment of an order covers every booking in it, so staff settle all of them at once
+		payments := []models.Payment{*payment}
+		if payment.OrderID != nil {
+			orderPayments, err := uc.bookingRepo.GetOrderPayments(ctx, *payment.OrderID)
+			if err != nil {
+				return fmt.Errorf("failed to get order payments: %w", err)
+			}
+			payments = orderPayments
+		}
+
+		for _, current := range payments {
+			// An order can span venues, the staff must work at every one of them
+			if current.ID != payment.ID {
+				if err := uc.authorizeVenueStaff(ctx, current.BookingID, userID); err != nil {
+					return err
+				}
+			}
+
+			current.Status = payment.Status
+			current.PaymentMethod = payment.PaymentMethod
+			current.UpdatedAt = payment.UpdatedAt
+			if err := uc.bookingRepo.UpdatePayment(ctx, &current); err != nil {
+				return fmt.Errorf("failed to update payment: %w", err)
+			}
+
+			// Update booking status based on payment status
+			if err := uc.handlePaymentStatus(ctx, &current); err != nil {
+				return fmt.Errorf("failed to update booking status: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Update booking status based on payment status
-	if err := uc.handlePaymentStatus(ctx, payment.BookingID, payment.Status); err != nil {
-		return nil, fmt.Errorf("failed to update booking status: %w", err)
+	return payment.ToResponse(), nil
+}
+
+// HandlePaymentWebhook applies a gateway notification about a card or QR payment.
+// Deliveries are retried by the gateway, so a payment that is no longer pending is left alone.
+func (uc *useCase) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := uc.paymentProvider.ParseWebhook(payload, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
 	}
 
-	return &responses.PaymentResponse{
-		ID:            payment.ID.String(),
-		Amount:        payment.Amount,
-		Status:        string(payment.Status),
-		PaymentMethod: string(payment.PaymentMethod),
-		TransactionID: *payment.TransactionID,
-		CreatedAt:     payment.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     payment.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	// The event only tells us which intent changed, its state comes from the gateway itself
+	intent, err := uc.paymentProvider.VerifyIntent(ctx, event.IntentID)
+	if err != nil {
+		return fmt.Errorf("failed to verify payment intent: %w", err)
+	}
+
+	var status models.PaymentStatus
+	switch intent.Status {
+	case gateway.StatusSucceeded:
+		status = models.PaymentStatusCompleted
+	case gateway.StatusFailed:
+		status = models.PaymentStatusFailed
+	default:
+		return nil
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// An order is paid with one intent, recorded as a payment per booking
+		payments, err := uc.bookingRepo.GetPaymentsByTransactionID(ctx, intent.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get payments: %w", err)
+		}
+		if len(payments) == 0 {
+			return fmt.Errorf("%w: no payment for intent %s", ErrPaymentNotFound, intent.ID)
+		}
+
+		var amount float64
+		for _, payment := range payments {
+			if payment.Status != models.PaymentStatusPending {
+				return nil
+			}
+			amount += payment.Amount
+		}
+
+		if status == models.PaymentStatusCompleted && math.Round(intent.Amount*100) != math.Round(amount*100) {
+			return fmt.Errorf("%w: paid amount does not match payment amount", ErrInvalidWebhook)
+		}
+
+		now := time.Now()
+		for i := range payments {
+			payment := &payments[i]
+			payment.Status = status
+			payment.UpdatedAt = now
+
+			if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
+				return fmt.Errorf("failed to update payment: %w", err)
+			}
+
+			if err := uc.handlePaymentStatus(ctx, payment); err != nil {
+				return fmt.Errorf("failed to update booking status: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// A payment that arrived after its booking was released is returned straight away
+	uc.settleRefunds(ctx)
+	return nil
 }
 
 // Helper methods
-func (uc *useCase) validateBookingTime(date time.Time, startTime, endTime time.Time, venue *models.Venue) error {
+func (uc *useCase) validateBookingTime(ctx context.Context, courtID uuid.UUID, date time.Time, startTime, endTime time.Time, venue *models.Venue) error {
 	now := time.Now()
 
 	// Check if date is in the future
@@ -495,44 +1795,29 @@ func (uc *useCase) validateBookingTime(date time.Time, startTime, endTime time.T
 	}
 
 	// Check venue operating hours
-	if err := uc.isVenueOpenForBooking(venue, date, startTime, endTime); err != nil {
+	if err := uc.isVenueOpenForBooking(ctx, venue, courtID, date, startTime, endTime); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// calculateBookingAmount calculates the total amount for a booking
-func (uc *useCase) calculateBookingAmount(startTime, endTime time.Time, pricePerHour float64) float64 {
-	duration := endTime.Sub(startTime)
-	hours := duration.Hours()
-	return hours * pricePerHour
-}
-func (uc *useCase) generateTimeSlots(ctx context.Context, courtID uuid.UUID, date time.Time, venue *models.Venue) ([]responses.TimeSlot, error) {
-	dayOfWeek := strings.ToLower(date.Weekday().String())
-
-	var openRanges []responses.OpenRangeResponse
-
-	if !venue.OpenRange.Valid {
-		return nil, fmt.Errorf("venue open range is invalid")
-	}
-	if err := json.Unmarshal(venue.OpenRange.RawMessage, &openRanges); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal open range: %w", err)
+// priceBooking prices a court's time range on a date, segment by segment, with the
+// venue's pricing rules, falling back to the court's own price outside of them
+func (uc *useCase) priceBooking(ctx context.Context, venueID, courtID uuid.UUID, basePrice float64, date, startTime, endTime time.Time) (float64, []models.PriceSegment, error) {
+	pricing, err := uc.pricingRepo.GetVenuePricing(ctx, venueID, date)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get pricing rules: %w", err)
 	}
 
-	var daySchedule *responses.OpenRangeResponse
-	for _, schedule := range openRanges {
-		if strings.EqualFold(schedule.Day, dayOfWeek) {
-			daySchedule = &schedule
-			break
-		}
-	}
-	if daySchedule == nil {
-		return nil, fmt.Errorf("venue is closed on %s", date.Weekday())
-	}
+	amount, segments := pricing.Price(courtID, basePrice, date, startTime, endTime)
+	return amount, segments, nil
+}
 
-	// Get existing bookings for the day
-	bookings, err := uc.bookingRepo.GetCourtBookings(ctx, courtID, date)
+// generateTimeSlots lists the free half-hour slots of a court on a date with the price of each
+func (uc *useCase) generateTimeSlots(ctx context.Context, court *models.Court, date time.Time, schedule *models.DaySchedule, pricing *models.VenuePricing) ([]responses.TimeSlot, error) {
+	// Get the bookings and session reservations holding the court that day
+	bookings, err := uc.bookingRepo.GetCourtsBookedSlots(ctx, []uuid.UUID{court.ID}, date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get court bookings: %w", err)
 	}
@@ -547,31 +1832,43 @@ func (uc *useCase) generateTimeSlots(ctx context.Context, courtID uuid.UUID, dat
 		}
 	}
 
-	// Generate available time slots
-	var slots []responses.TimeSlot
-	for t := daySchedule.OpenTime; t.Before(daySchedule.CloseTime); t = t.Add(30 * time.Minute) {
-		if !bookedTimes[t.Format("15:04")] {
-			endTime := t.Add(30 * time.Minute)
-			if !endTime.After(daySchedule.CloseTime) {
-				slots = append(slots, responses.TimeSlot{
-					StartTime: t.Format("15:04"),
-					EndTime:   endTime.Format("15:04"),
-				})
-			}
+	// Generate available time slots, leaving out any the day's schedule does not allow
+	slots := []responses.TimeSlot{}
+	for _, slot := range schedule.Slots() {
+		startTime, endTime := slot[0], slot[1]
+		if !bookedTimes[startTime.Format("15:04")] {
+			price, _ := pricing.Price(court.ID, court.PricePerHour, date, startTime, endTime)
+			slots = append(slots, responses.TimeSlot{
+				StartTime: startTime.Format("15:04"),
+				EndTime:   endTime.Format("15:04"),
+				Price:     price,
+			})
 		}
 	}
 
 	return slots, nil
 }
 
-// handlePaymentStatus updates booking status based on payment status
-func (uc *useCase) handlePaymentStatus(ctx context.Context, bookingID uuid.UUID, paymentStatus models.PaymentStatus) error {
-	booking, err := uc.bookingRepo.GetByID(ctx, bookingID)
+// handlePaymentStatus updates booking status based on payment status. A cancelled booking
+// is never brought back: its slot may be booked again, so a payment completing after the
+// cancellation is refunded in full and any other outcome is ignored.
+func (uc *useCase) handlePaymentStatus(ctx context.Context, payment *models.Payment) error {
+	booking, err := uc.bookingRepo.GetByID(ctx, payment.BookingID)
 	if err != nil {
 		return fmt.Errorf("booking not found: %w", err)
 	}
 
-	switch paymentStatus {
+	if booking.Status == models.BookingStatusCancelled {
+		if payment.Status == models.PaymentStatusCompleted {
+			booking.Payment = payment
+			return uc.refundLatePayment(ctx, booking)
+		}
+		return nil
+	}
+
+	previousStatus := booking.Status
+
+	switch payment.Status {
 	case models.PaymentStatusCompleted:
 		booking.Status = models.BookingStatusConfirmed
 	case models.PaymentStatusFailed:
@@ -586,53 +1883,162 @@ func (uc *useCase) handlePaymentStatus(ctx context.Context, bookingID uuid.UUID,
 		return fmt.Errorf("failed to update booking status: %w", err)
 	}
 
+	if booking.Status != previousStatus {
+		history := models.NewBookingStatusHistory(booking.ID, previousStatus, booking.Status, models.BookingReasonPaymentUpdated, nil)
+		if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+			return fmt.Errorf("failed to record booking status: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// validateRefundEligibility checks if a booking is eligible for refund
-func (uc *useCase) validateRefundEligibility(booking *models.CourtBooking) error {
-	if booking.Status != models.BookingStatusConfirmed {
-		return fmt.Errorf("booking must be confirmed to be eligible for refund")
+// processRefund refunds the completed payment of a cancelled booking by the share the
+// venue's cancellation policy grants for how far ahead of the start it was cancelled
+func (uc *useCase) processRefund(ctx context.Context, booking *models.CourtBooking, cancelledAt time.Time) (*models.Refund, error) {
+	court, err := uc.courtRepo.GetByID(ctx, booking.CourtID)
+	if err != nil {
+		return nil, fmt.Errorf("court not found: %w", err)
 	}
 
-	if booking.Payment == nil || booking.Payment.Status != models.PaymentStatusCompleted {
-		return fmt.Errorf("no completed payment found for booking")
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("venue not found: %w", err)
 	}
 
-	// Check cancellation deadline (24 hours before start time)
-	bookingStart := time.Date(
-		booking.Date.Year(), booking.Date.Month(), booking.Date.Day(),
-		booking.StartTime.Hour(), booking.StartTime.Minute(), 0, 0, time.Local)
+	tiers, err := venue.CancellationTiers()
+	if err != nil {
+		return nil, err
+	}
 
-	if time.Now().After(bookingStart.Add(-24 * time.Hour)) {
-		return fmt.Errorf("cancellation deadline has passed (24 hours before start time)")
+	payment := booking.Payment
+	percent := models.RefundPercent(tiers, booking.StartsAt().Sub(cancelledAt))
+	refund := &models.Refund{
+		ID:            uuid.New(),
+		BookingID:     booking.ID,
+		PaymentID:     payment.ID,
+		Amount:        math.Round(payment.Amount*float64(percent)) / 100,
+		RefundPercent: percent,
+		Reason:        models.BookingReasonCancelledByUser,
+		CreatedAt:     cancelledAt,
 	}
 
-	return nil
-}
+	// Past the last deadline the payment is kept in full
+	if refund.Amount <= 0 {
+		return refund, nil
+	}
 
-// processRefund handles the refund process for a cancelled booking
-func (uc *useCase) processRefund(ctx context.Context, booking *models.CourtBooking) error {
-	if err := uc.validateRefundEligibility(booking); err != nil {
-		return fmt.Errorf("refund not eligible: %w", err)
+	if err := uc.issueRefund(ctx, payment, refund); err != nil {
+		return nil, err
 	}
 
-	// Update payment status
+	return refund, nil
+}
+
+// refundLatePayment returns a payment in full when it completed after its booking was released
+func (uc *useCase) refundLatePayment(ctx context.Context, booking *models.CourtBooking) error {
 	payment := booking.Payment
+	refund := &models.Refund{
+		ID:            uuid.New(),
+		BookingID:     booking.ID,
+		PaymentID:     payment.ID,
+		Amount:        payment.Amount,
+		RefundPercent: 100,
+		Reason:        models.BookingReasonPaidAfterExpiry,
+		CreatedAt:     time.Now(),
+	}
+
+	return uc.issueRefund(ctx, payment, refund)
+}
+
+// issueRefund records a refund and marks its payment refunded. Refunds of gateway payments
+// are recorded as pending and only sent to the gateway by SettleRefunds once the transaction
+// has committed, so a rollback can never leave money returned without a record of it.
+func (uc *useCase) issueRefund(ctx context.Context, payment *models.Payment, refund *models.Refund) error {
+	refund.Status = models.RefundStatusCompleted
+	if payment.PaymentMethod.UsesGateway() && payment.TransactionID != nil {
+		refund.Status = models.RefundStatusPending
+	}
+
+	if err := uc.bookingRepo.CreateRefund(ctx, refund); err != nil {
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+
 	payment.Status = models.PaymentStatusRefunded
-	payment.UpdatedAt = time.Now()
+	payment.UpdatedAt = refund.CreatedAt
 
 	if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
 		return fmt.Errorf("failed to update payment status: %w", err)
 	}
 
-	// Update booking status
-	booking.Status = models.BookingStatusCancelled
-	booking.CancelledAt = toPtr(time.Now())
-	booking.UpdatedAt = time.Now()
+	return nil
+}
 
-	if err := uc.bookingRepo.Update(ctx, booking); err != nil {
-		return fmt.Errorf("failed to update booking status: %w", err)
+// SettleRefunds sends pending refunds to the payment gateway. The refund ID is the idempotency
+// key, so a refund sent again after a crash or by another instance is only paid out once.
+// Refunds the gateway rejects stay pending and are retried on the next run.
+func (uc *useCase) SettleRefunds(ctx context.Context) error {
+	refunds, err := uc.bookingRepo.GetPendingRefunds(ctx, refundBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get pending refunds: %w", err)
+	}
+
+	var errs []error
+	for _, refund := range refunds {
+		if refund.TransactionID == nil {
+			errs = append(errs, fmt.Errorf("refund %s: payment has no transaction", refund.ID))
+			continue
+		}
+
+		providerRefund, err := uc.paymentProvider.Refund(ctx, *refund.TransactionID, refund.Amount, refund.ID.String())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("refund %s: %w", refund.ID, err))
+			continue
+		}
+
+		if err := uc.bookingRepo.CompleteRefund(ctx, refund.ID, providerRefund.ID); err != nil {
+			errs = append(errs, fmt.Errorf("refund %s: failed to record settlement: %w", refund.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// settleRefunds sends the refunds of a request that has just committed. Those the gateway
+// does not take now are left pending for the cron job, so the request still succeeds.
+func (uc *useCase) settleRefunds(ctx context.Context) {
+	if err := uc.SettleRefunds(ctx); err != nil {
+		log.Printf("failed to settle refunds: %v", err)
+	}
+}
+
+// authorizeVenueStaff checks that the user is an admin or owns the venue of the booking
+func (uc *useCase) authorizeVenueStaff(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if user.Role == string(models.UserRoleAdmin) {
+		return nil
+	}
+
+	booking, err := uc.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("booking not found: %w", err)
+	}
+
+	court, err := uc.courtRepo.GetByID(ctx, booking.CourtID)
+	if err != nil {
+		return fmt.Errorf("court not found: %w", err)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		return fmt.Errorf("venue not found: %w", err)
+	}
+
+	if venue.OwnerID != userID {
+		return fmt.Errorf("%w: only venue staff can update this payment", ErrUnauthorized)
 	}
 
 	return nil
@@ -692,57 +2098,130 @@ func (uc *useCase) validatePaymentCreate(booking *models.CourtBooking, amount fl
 
 	return nil
 }
-func (uc *useCase) isVenueOpenForBooking(venue *models.Venue, date time.Time, startTime, endTime time.Time) error {
-	dayOfWeek := strings.ToLower(date.Weekday().String())
-	var openRanges []responses.OpenRangeResponse
-	if !venue.OpenRange.Valid {
-		return fmt.Errorf("venue open range is invalid")
+func (uc *useCase) isVenueOpenForBooking(ctx context.Context, venue *models.Venue, courtID uuid.UUID, date time.Time, startTime, endTime time.Time) error {
+	schedule, err := uc.daySchedule(ctx, venue, courtID, date)
+	if err != nil {
+		return err
 	}
-	if err := json.Unmarshal(venue.OpenRange.RawMessage, &openRanges); err != nil {
-		return fmt.Errorf("failed to unmarshal open range: %w", err)
+
+	return schedule.Check(startTime, endTime)
+}
+
+// daySchedule works out when a court can be booked on a date from the venue's weekly
+// hours, the overrides set for that date and the court's maintenance
+func (uc *useCase) daySchedule(ctx context.Context, venue *models.Venue, courtID uuid.UUID, date time.Time) (*models.DaySchedule, error) {
+	schedule, err := models.WeeklySchedule(venue.OpenRange, date)
+	if err != nil {
+		return nil, err
 	}
 
-	var daySchedule *responses.OpenRangeResponse
-	for _, schedule := range openRanges {
-		if strings.EqualFold(schedule.Day, dayOfWeek) {
-			daySchedule = &schedule
-			break
+	overrides, err := uc.scheduleRepo.GetDateOverrides(ctx, venue.ID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule overrides: %w", err)
+	}
+	schedule.ApplyOverrides(overrides, courtID)
+
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, models.BookingTimeZone)
+	windows, err := uc.maintenanceRepo.GetOverlapping(ctx, courtID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get court maintenance: %w", err)
+	}
+	schedule.ApplyMaintenance(date, windows)
+
+	return schedule, nil
+}
+
+// courtFree reports whether neither a booking nor a session holds a court during a slot.
+// Sessions that booked their courts hold a court booking too, but a session created without
+// booking only reserves its courts in session_courts.
+func (uc *useCase) courtFree(ctx context.Context, courtID uuid.UUID, date, startTime, endTime time.Time) (bool, error) {
+	available, err := uc.bookingRepo.CheckCourtAvailability(ctx, courtID, date, startTime, endTime)
+	if err != nil {
+		return false, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available {
+		return false, nil
+	}
+
+	sessions, err := uc.sessionRepo.GetCourtSessions(ctx, courtID, date)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session reservations: %w", err)
+	}
+
+	slotStart, slotEnd := minuteOfDay(startTime, false), minuteOfDay(endTime, true)
+	for _, session := range sessions {
+		if session.Status == models.SessionStatusCompleted {
+			continue
+		}
+		if minuteOfDay(session.StartTime, false) < slotEnd && slotStart < minuteOfDay(session.EndTime, true) {
+			return false, nil
 		}
 	}
 
-	if !daySchedule.IsOpen {
-		return fmt.Errorf("venue is closed on %s", date.Weekday())
+	return true, nil
+}
+
+// minuteOfDay returns the minutes since midnight of a time of day. An end of 00:00 is midnight
+// at the end of the day.
+func minuteOfDay(t time.Time, end bool) int {
+	minute := t.Hour()*60 + t.Minute()
+	if end && minute == 0 {
+		return 24 * 60
 	}
+	return minute
+}
 
-	// Convert schedule times to same date as booking for comparison
-	scheduleOpen := time.Date(
-		date.Year(), date.Month(), date.Day(),
-		daySchedule.OpenTime.Hour(), daySchedule.OpenTime.Minute(), 0, 0,
-		date.Location(),
-	)
-	scheduleClose := time.Date(
-		date.Year(), date.Month(), date.Day(),
-		daySchedule.CloseTime.Hour(), daySchedule.CloseTime.Minute(), 0, 0,
-		date.Location(),
-	)
+// bookedDuring reports whether any of the bookings of a date overlaps a slot on that date
+func bookedDuring(bookings []models.CourtBooking, date, startTime, endTime time.Time) bool {
+	slotStart := time.Date(date.Year(), date.Month(), date.Day(), startTime.Hour(), startTime.Minute(), 0, 0, models.BookingTimeZone)
+	slotEnd := slotStart.Add(endTime.Sub(startTime))
 
-	if startTime.Hour() < scheduleOpen.Hour() ||
-		(startTime.Hour() == scheduleOpen.Hour() && startTime.Minute() < scheduleOpen.Minute()) ||
-		endTime.Hour() > scheduleClose.Hour() ||
-		(endTime.Hour() == scheduleClose.Hour() && endTime.Minute() > scheduleClose.Minute()) {
-		fmt.Println("not")
-		return fmt.Errorf("booking must be within venue operating hours (%s - %s)",
-			daySchedule.OpenTime.Format("15:04"), daySchedule.CloseTime.Format("15:04"))
+	for i := range bookings {
+		if bookings[i].StartsAt().Before(slotEnd) && slotStart.Before(bookings[i].EndsAt()) {
+			return true
+		}
 	}
+	return false
+}
 
-	return nil
+// ExpirePendingBookings releases the slots of pending bookings that were not paid within the venue's hold window
+func (uc *useCase) ExpirePendingBookings(ctx context.Context) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		expired, err := uc.bookingRepo.ExpireHolds(ctx, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to expire pending bookings: %w", err)
+		}
+
+		for _, booking := range expired {
+			// The promotion used on an unpaid booking can be used again
+			if booking.PromotionID != nil {
+				if err := uc.promotionRepo.ReleaseRedemption(ctx, booking.ID); err != nil {
+					return fmt.Errorf("failed to release promotion: %w", err)
+				}
+			}
+
+			// Courts booked for a session are no longer reserved for it
+			if booking.SessionID == nil {
+				continue
+			}
+			if err := uc.sessionRepo.RemoveSessionCourt(ctx, *booking.SessionID, booking.CourtID); err != nil {
+				return fmt.Errorf("failed to release session court: %w", err)
+			}
+		}
+
+		return nil
+	})
 }
 
-// cronjob for all bookings if time.now in range of date , start time and end time then change court status to occupied if dont have any booking in that time slot status will be available
+// ChangeCourtStatus is run every minute to derive each court's live status: under maintenance
+// while a maintenance window is in progress, occupied while a confirmed booking is being
+// played and available otherwise
 func (uc *useCase) ChangeCourtStatus(ctx context.Context) error {
+	currentTime := time.Now().In(models.BookingTimeZone)
+
 	filters := make(map[string]interface{})
 	filters["status"] = string(models.BookingStatusConfirmed)
-	filters["date"] = time.Now().Format("2006-01-02")
+	filters["date"] = currentTime.Format("2006-01-02")
 
 	// Get all confirmed bookings for today
 	bookings, err := uc.bookingRepo.List(ctx, uuid.Nil, filters, 0, 0)
@@ -750,55 +2229,38 @@ func (uc *useCase) ChangeCourtStatus(ctx context.Context) error {
 		return fmt.Errorf("failed to get all bookings: %w", err)
 	}
 
-	var thailandTZ = time.FixedZone("ICT", 7*3600)
-	currentTime := time.Now().In(thailandTZ)
-
-	// Track courts with active bookings to avoid setting them to available later
-	occupiedCourts := make(map[uuid.UUID]bool)
+	windows, err := uc.maintenanceRepo.GetActive(ctx, currentTime)
+	if err != nil {
+		return fmt.Errorf("failed to get active maintenance: %w", err)
+	}
 
+	statuses := make(map[uuid.UUID]models.CourtStatus)
 	for _, booking := range bookings {
-		// Convert booking times to ICT timezone to match currentTime
-		startTime := time.Date(
-			booking.Date.Year(),
-			booking.Date.Month(),
-			booking.Date.Day(),
-			booking.StartTime.Hour(),
-			booking.StartTime.Minute(),
-			0, 0,
-			thailandTZ, // Use ICT timezone
-		)
-
-		endTime := time.Date(
-			booking.Date.Year(),
-			booking.Date.Month(),
-			booking.Date.Day(),
-			booking.EndTime.Hour(),
-			booking.EndTime.Minute(),
-			0, 0,
-			thailandTZ, // Use ICT timezone
-		)
-
-		if currentTime.After(startTime) && currentTime.Before(endTime) {
-			err := uc.courtRepo.UpdateStatus(ctx, booking.CourtID, models.CourtStatusOccupied)
-			if err != nil {
-				return fmt.Errorf("failed to update court status to occupied: %w", err)
-			}
-			occupiedCourts[booking.CourtID] = true
+		if currentTime.After(booking.StartsAt()) && currentTime.Before(booking.EndsAt()) {
+			statuses[booking.CourtID] = models.CourtStatusOccupied
 		}
 	}
+	// Maintenance wins over a booking that was made before it was scheduled
+	for _, window := range windows {
+		statuses[window.CourtID] = models.CourtStatusMaintenance
+	}
 
-	// Set courts without active bookings to available
 	allCourts, err := uc.courtRepo.List(ctx, nil, 0, 0)
 	if err != nil {
 		return fmt.Errorf("failed to list all courts: %w", err)
 	}
 
 	for _, court := range allCourts {
-		if !occupiedCourts[court.ID] {
-			err := uc.courtRepo.UpdateStatus(ctx, court.ID, models.CourtStatusAvailable)
-			if err != nil {
-				return fmt.Errorf("failed to update court status to available: %w", err)
-			}
+		status, ok := statuses[court.ID]
+		if !ok {
+			status = models.CourtStatusAvailable
+		}
+		if court.Status == status {
+			continue
+		}
+
+		if err := uc.courtRepo.UpdateStatus(ctx, court.ID, status); err != nil {
+			return fmt.Errorf("failed to update court status to %s: %w", status, err)
 		}
 	}
 
@@ -11,7 +11,9 @@ import (
 type UseCase interface {
 	GetChatMessageByID(ctx context.Context, chatID uuid.UUID, limit int, offset int, userID uuid.UUID) (*responses.ChatMassageListResponse, error)
 
-	SendMessage(ctx context.Context, userID uuid.UUID, chatID uuid.UUID, req requests.SendAndUpdateMessageRequest) (*responses.ChatMassageResponse, error)
+	// SendMessage reports whether the message is new; a message resent with the same client
+	// message ID returns the one already sent, which must not be broadcast again
+	SendMessage(ctx context.Context, userID uuid.UUID, chatID uuid.UUID, req requests.SendAndUpdateMessageRequest) (*responses.ChatMassageResponse, bool, error)
 
 	DeleteMessage(ctx context.Context, chatID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) error
 
@@ -24,4 +26,10 @@ type UseCase interface {
 	GetDirectChat(ctx context.Context, userID uuid.UUID, otherUserUUID uuid.UUID, limit int, offset int) (*responses.ChatMassageListResponse, error)
 
 	GetChatMessageOfSession(ctx context.Context, sessionID uuid.UUID, limit int, offset int, userID uuid.UUID) (*responses.ChatMassageListResponse, error)
-}
\ No newline at end of file
+
+	// CheckChatMember returns ErrUnauthorized unless the user belongs to the chat
+	CheckChatMember(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error
+
+	// MarkChatAsRead marks the messages other members sent to the chat as read by the user
+	MarkChatAsRead(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error
+}
@@ -6,7 +6,10 @@ import (
 	"badbuddy/internal/domain/models"
 	"badbuddy/internal/repositories/interfaces"
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -17,8 +20,26 @@ var (
 	ErrValidation = errors.New("validation error")
 
 	ErrChatNotFound = errors.New("chat not found")
+
+	ErrMessageNotFound = errors.New("message not found")
 )
 
+// maxMessageLength is the longest message, in characters, a user may send
+const maxMessageLength = 2000
+
+// maxClientMessageIDLength matches the size of chat_messages.client_message_id
+const maxClientMessageIDLength = 100
+
+func validateMessage(message string) error {
+	if message == "" {
+		return ErrValidation
+	}
+	if utf8.RuneCountInString(message) > maxMessageLength {
+		return fmt.Errorf("%w: message must be at most %d characters", ErrValidation, maxMessageLength)
+	}
+	return nil
+}
+
 type useCase struct {
 	chatRepo interfaces.ChatRepository
 	userRepo interfaces.UserRepository
@@ -83,22 +104,25 @@ func (uc *useCase) GetChatMessageByID(ctx context.Context, chatID uuid.UUID, lim
 
 }
 
-func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, req requests.SendAndUpdateMessageRequest) (*responses.ChatMassageResponse, error) {
-	if req.Message == "" {
-		return nil, ErrValidation
+func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, req requests.SendAndUpdateMessageRequest) (*responses.ChatMassageResponse, bool, error) {
+	if err := validateMessage(req.Message); err != nil {
+		return nil, false, err
+	}
+	if req.ClientMessageID != nil && (*req.ClientMessageID == "" || len(*req.ClientMessageID) > maxClientMessageIDLength) {
+		return nil, false, fmt.Errorf("%w: client message ID must be 1 to %d characters", ErrValidation, maxClientMessageIDLength)
 	}
 
 	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if !isPartOfChat {
-		return nil, ErrUnauthorized
+		return nil, false, ErrUnauthorized
 	}
 
 	_, err = uc.chatRepo.GetChatByID(ctx, chatID)
 	if err != nil {
-		return nil, ErrChatNotFound
+		return nil, false, ErrChatNotFound
 	}
 
 	message := models.Message{
@@ -108,11 +132,13 @@ func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, re
 		Type:     models.MessageTypeText,
 		Content:  req.Message,
 		Status:   models.MessageStatusSent,
+
+		ClientMessageID: req.ClientMessageID,
 	}
 
 	messageReturn, err := uc.chatRepo.SaveMessage(ctx, &message)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	chatMessage := responses.ChatMassageResponse{
@@ -133,42 +159,43 @@ func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, re
 		Message:       messageReturn.Content,
 		Timestamp:     messageReturn.CreatedAt,
 		EditTimeStamp: messageReturn.UpdatedAt,
+
+		ClientMessageID: messageReturn.ClientMessageID,
 	}
 
-	return &chatMessage, nil
+	// A resent message resolves to the one saved the first time
+	created := messageReturn.ID == message.ID
+	return &chatMessage, created, nil
 }
 
 func (uc *useCase) DeleteMessage(ctx context.Context, chatID, messageID, userID uuid.UUID) error {
-	isUserIsSerder, err := uc.chatRepo.IsUserIsSender(ctx, userID, messageID)
-	if err != nil {
+	if _, err := uc.getOwnMessage(ctx, chatID, messageID, userID); err != nil {
 		return err
 	}
-	if !isUserIsSerder {
-		return ErrUnauthorized
-	}
 
-	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
+	err := uc.chatRepo.DeleteChatMessage(ctx, messageID)
 	if err != nil {
 		return err
 	}
-	if !isPartOfChat {
-		return ErrUnauthorized
-	}
 
-	message, err := uc.chatRepo.GetChatMessageByID(ctx, chatID, 1, 0)
-	if err != nil {
+	return nil
+}
+
+func (uc *useCase) UpdateMessage(ctx context.Context, chatID, messageID, userID uuid.UUID, req requests.SendAndUpdateMessageRequest) error {
+	if err := validateMessage(req.Message); err != nil {
 		return err
 	}
 
-	if len(*message) == 0 {
-		return ErrChatNotFound
+	if _, err := uc.getOwnMessage(ctx, chatID, messageID, userID); err != nil {
+		return err
 	}
 
-	if (*message)[0].SenderID != userID {
-		return ErrUnauthorized
+	messageToUpdate := models.Message{
+		ID:      messageID,
+		Content: req.Message,
 	}
 
-	err = uc.chatRepo.DeleteChatMessage(ctx, messageID)
+	err := uc.chatRepo.UpdateChatMessage(ctx, &messageToUpdate)
 	if err != nil {
 		return err
 	}
@@ -176,48 +203,33 @@ func (uc *useCase) DeleteMessage(ctx context.Context, chatID, messageID, userID
 	return nil
 }
 
-func (uc *useCase) UpdateMessage(ctx context.Context, chatID, messageID, userID uuid.UUID, req requests.SendAndUpdateMessageRequest) error {
-	isUserIsSerder, err := uc.chatRepo.IsUserIsSender(ctx, userID, messageID)
-	if err != nil {
-		return err
-	}
-	if !isUserIsSerder {
-		return ErrUnauthorized
-	}
-
+// getOwnMessage loads a message of the chat that the user sent and can still change
+func (uc *useCase) getOwnMessage(ctx context.Context, chatID, messageID, userID uuid.UUID) (*models.Message, error) {
 	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
 	if !isPartOfChat {
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 
-	message, err := uc.chatRepo.GetChatMessageByID(ctx, chatID, 1, 0)
+	message, err := uc.chatRepo.GetMessageByID(ctx, messageID)
 	if err != nil {
-		return err
-	}
-
-	if len(*message) == 0 {
-		return ErrChatNotFound
-	}
-
-	if (*message)[0].SenderID != userID {
-		return ErrUnauthorized
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
 	}
 
-	messageToUpdate := models.Message{
-		ID:      messageID,
-		Content: req.Message,
+	if message.ChatID != chatID || message.DeletedAt != nil {
+		return nil, ErrMessageNotFound
 	}
 
-	err = uc.chatRepo.UpdateChatMessage(ctx, &messageToUpdate)
-	if err != nil {
-		return err
+	if message.SenderID != userID {
+		return nil, ErrUnauthorized
 	}
 
-	return nil
+	return message, nil
 }
 
 func (uc *useCase) GetChats(ctx context.Context, userID uuid.UUID) (*responses.ChatListResponse, error) {
@@ -232,7 +244,13 @@ func (uc *useCase) GetChats(ctx context.Context, userID uuid.UUID) (*responses.C
 		chatList = append(chatList, responses.ChatResponse{
 			ID:   c.ID.String(),
 			Type: string(c.Type),
-			SessionID:  func() string { if c.SessionID == nil { return "" } else { return c.SessionID.String() } }(),
+			SessionID: func() string {
+				if c.SessionID == nil {
+					return ""
+				} else {
+					return c.SessionID.String()
+				}
+			}(),
 			LastMessage: func() *responses.ChatMassageResponse {
 				if c.LastMessage == nil {
 					return nil
@@ -294,7 +312,6 @@ func (uc *useCase) GetDirectChat(ctx context.Context, userID uuid.UUID, otherUse
 		return nil, ErrValidation
 	}
 
-
 	chat_id, err := uc.chatRepo.GetDirectChatID(ctx, userID, otherUserUUID)
 	if err != nil || chat_id == uuid.Nil {
 		return nil, err
@@ -362,3 +379,23 @@ func convertToUserChatResponse(users []models.User) []responses.UserChatResponse
 
 	return userResponses
 }
+
+func (uc *useCase) CheckChatMember(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !isPartOfChat {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+func (uc *useCase) MarkChatAsRead(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	if err := uc.CheckChatMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	return uc.chatRepo.UpdateChatMessageReadStatus(ctx, chatID, userID)
+}
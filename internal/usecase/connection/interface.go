@@ -0,0 +1,35 @@
+package connection
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	SendRequest(ctx context.Context, userID uuid.UUID, req requests.SendConnectionRequest) (*responses.ConnectionResponse, error)
+	AcceptRequest(ctx context.Context, connectionID, userID uuid.UUID) (*responses.ConnectionResponse, error)
+	DeclineRequest(ctx context.Context, connectionID, userID uuid.UUID) error
+	RemoveConnection(ctx context.Context, connectionID, userID uuid.UUID) error
+	ListConnections(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.ConnectionListResponse, error)
+	ListRequests(ctx context.Context, userID uuid.UUID) (*responses.ConnectionRequestsResponse, error)
+	GetMutualConnections(ctx context.Context, userID, otherID uuid.UUID) ([]responses.ConnectionResponse, error)
+	GetPartnerSuggestions(ctx context.Context, userID uuid.UUID, minSessions, limit int) ([]responses.PartnerSuggestionResponse, error)
+}
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrValidation = errors.New("validation error")
+
+	ErrUserNotFound = errors.New("user not found")
+
+	ErrConnectionNotFound = errors.New("connection not found")
+
+	ErrAlreadyConnected = interfaces.ErrDuplicateConnection
+)
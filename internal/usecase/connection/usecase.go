@@ -0,0 +1,285 @@
+package connection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const defaultMinSessionsTogether = 2
+
+type useCase struct {
+	connectionRepo interfaces.ConnectionRepository
+	userRepo       interfaces.UserRepository
+}
+
+func NewConnectionUseCase(connectionRepo interfaces.ConnectionRepository, userRepo interfaces.UserRepository) UseCase {
+	return &useCase{
+		connectionRepo: connectionRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (uc *useCase) SendRequest(ctx context.Context, userID uuid.UUID, req requests.SendConnectionRequest) (*responses.ConnectionResponse, error) {
+	targetID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID", ErrValidation)
+	}
+
+	if targetID == userID {
+		return nil, fmt.Errorf("%w: cannot connect with yourself", ErrValidation)
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, targetID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	existing, err := uc.connectionRepo.GetBetween(ctx, userID, targetID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check existing connection: %w", err)
+	}
+
+	if existing != nil {
+		// The other player already asked us, so sending back means accepting
+		if existing.Status == models.ConnectionStatusPending && existing.Player2ID == userID {
+			return uc.AcceptRequest(ctx, existing.ID, userID)
+		}
+		return nil, ErrAlreadyConnected
+	}
+
+	now := time.Now()
+	connection := &models.PlayerConnection{
+		ID:        uuid.New(),
+		Player1ID: userID,
+		Player2ID: targetID,
+		Status:    models.ConnectionStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.connectionRepo.Create(ctx, connection); err != nil {
+		if errors.Is(err, interfaces.ErrDuplicateConnection) {
+			return nil, ErrAlreadyConnected
+		}
+		return nil, fmt.Errorf("failed to create connection request: %w", err)
+	}
+
+	return uc.getConnectionResponse(ctx, userID, targetID)
+}
+
+func (uc *useCase) AcceptRequest(ctx context.Context, connectionID, userID uuid.UUID) (*responses.ConnectionResponse, error) {
+	connection, err := uc.getConnection(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the receiving player can accept a request
+	if connection.Player2ID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if connection.Status != models.ConnectionStatusPending {
+		return nil, fmt.Errorf("%w: connection request is not pending", ErrValidation)
+	}
+
+	if err := uc.connectionRepo.UpdateStatus(ctx, connectionID, models.ConnectionStatusAccepted); err != nil {
+		return nil, fmt.Errorf("failed to accept connection request: %w", err)
+	}
+
+	return uc.getConnectionResponse(ctx, userID, connection.Player1ID)
+}
+
+func (uc *useCase) DeclineRequest(ctx context.Context, connectionID, userID uuid.UUID) error {
+	connection, err := uc.getConnection(ctx, connectionID)
+	if err != nil {
+		return err
+	}
+
+	if connection.Player2ID != userID {
+		return ErrUnauthorized
+	}
+
+	if connection.Status != models.ConnectionStatusPending {
+		return fmt.Errorf("%w: connection request is not pending", ErrValidation)
+	}
+
+	if err := uc.connectionRepo.Delete(ctx, connectionID); err != nil {
+		return fmt.Errorf("failed to decline connection request: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) RemoveConnection(ctx context.Context, connectionID, userID uuid.UUID) error {
+	connection, err := uc.getConnection(ctx, connectionID)
+	if err != nil {
+		return err
+	}
+
+	// Either side can remove a connection, and the sender can withdraw a pending request
+	if connection.Player1ID != userID && connection.Player2ID != userID {
+		return ErrUnauthorized
+	}
+
+	if err := uc.connectionRepo.Delete(ctx, connectionID); err != nil {
+		return fmt.Errorf("failed to remove connection: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) ListConnections(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.ConnectionListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	connections, err := uc.connectionRepo.ListConnections(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	total, err := uc.connectionRepo.CountConnections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count connections: %w", err)
+	}
+
+	return &responses.ConnectionListResponse{
+		Connections: toConnectionResponses(connections, userID),
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}, nil
+}
+
+func (uc *useCase) ListRequests(ctx context.Context, userID uuid.UUID) (*responses.ConnectionRequestsResponse, error) {
+	incoming, err := uc.connectionRepo.ListIncomingRequests(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incoming requests: %w", err)
+	}
+
+	outgoing, err := uc.connectionRepo.ListOutgoingRequests(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outgoing requests: %w", err)
+	}
+
+	return &responses.ConnectionRequestsResponse{
+		Incoming: toConnectionResponses(incoming, userID),
+		Outgoing: toConnectionResponses(outgoing, userID),
+	}, nil
+}
+
+func (uc *useCase) GetMutualConnections(ctx context.Context, userID, otherID uuid.UUID) ([]responses.ConnectionResponse, error) {
+	if userID == otherID {
+		return nil, fmt.Errorf("%w: cannot compare connections with yourself", ErrValidation)
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, otherID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	connections, err := uc.connectionRepo.GetMutualConnections(ctx, userID, otherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mutual connections: %w", err)
+	}
+
+	return toConnectionResponses(connections, userID), nil
+}
+
+func (uc *useCase) GetPartnerSuggestions(ctx context.Context, userID uuid.UUID, minSessions, limit int) ([]responses.PartnerSuggestionResponse, error) {
+	if minSessions <= 0 {
+		minSessions = defaultMinSessionsTogether
+	}
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+
+	partners, err := uc.connectionRepo.GetPlayingPartners(ctx, userID, minSessions, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playing partners: %w", err)
+	}
+
+	suggestions := make([]responses.PartnerSuggestionResponse, len(partners))
+	for i, partner := range partners {
+		suggestions[i] = responses.PartnerSuggestionResponse{
+			User: responses.ConnectionUserResponse{
+				ID:        partner.UserID.String(),
+				FirstName: partner.FirstName,
+				LastName:  partner.LastName,
+				AvatarURL: partner.AvatarURL,
+				PlayLevel: string(partner.PlayLevel),
+			},
+			SessionsTogether: partner.SessionsTogether,
+			LastPlayedAt:     partner.LastPlayedAt.Format("2006-01-02"),
+		}
+	}
+
+	return suggestions, nil
+}
+
+// Helper functions
+
+func (uc *useCase) getConnection(ctx context.Context, id uuid.UUID) (*models.PlayerConnection, error) {
+	connection, err := uc.connectionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrConnectionNotFound
+		}
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	return connection, nil
+}
+
+func (uc *useCase) getConnectionResponse(ctx context.Context, userID, otherID uuid.UUID) (*responses.ConnectionResponse, error) {
+	connection, err := uc.connectionRepo.GetBetween(ctx, userID, otherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	response := toConnectionResponse(connection, userID)
+	return &response, nil
+}
+
+func toConnectionResponses(connections []models.PlayerConnection, userID uuid.UUID) []responses.ConnectionResponse {
+	connectionResponses := make([]responses.ConnectionResponse, len(connections))
+	for i := range connections {
+		connectionResponses[i] = toConnectionResponse(&connections[i], userID)
+	}
+	return connectionResponses
+}
+
+func toConnectionResponse(connection *models.PlayerConnection, userID uuid.UUID) responses.ConnectionResponse {
+	direction := ""
+	if connection.Status == models.ConnectionStatusPending {
+		direction = "outgoing"
+		if connection.Player2ID == userID {
+			direction = "incoming"
+		}
+	}
+
+	return responses.ConnectionResponse{
+		ID:        connection.ID.String(),
+		Status:    string(connection.Status),
+		Direction: direction,
+		User: responses.ConnectionUserResponse{
+			ID:        connection.OtherUserID.String(),
+			FirstName: connection.OtherFirstName,
+			LastName:  connection.OtherLastName,
+			AvatarURL: connection.OtherAvatarURL,
+			PlayLevel: string(connection.OtherPlayLevel),
+		},
+		CreatedAt: connection.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
@@ -16,4 +17,23 @@ type UseCase interface {
 	ListCourts(ctx context.Context, req requests.ListCourtsRequest) (*responses.CourtListResponse, error)
 	GetVenueCourts(ctx context.Context, venueID uuid.UUID) ([]responses.CourtResponse, error)
 	UpdateCourtStatus(ctx context.Context, id uuid.UUID, status string) error
+	IsOwner(ctx context.Context, courtID uuid.UUID, userID uuid.UUID) (bool, error)
+	ScheduleMaintenance(ctx context.Context, courtID uuid.UUID, userID uuid.UUID, req requests.CreateMaintenanceWindowRequest) (*responses.MaintenanceWindowResponse, error)
+	CancelMaintenance(ctx context.Context, courtID uuid.UUID, windowID uuid.UUID) error
+	// ListMaintenance lists the maintenance of a court that has not ended yet
+	ListMaintenance(ctx context.Context, courtID uuid.UUID) ([]responses.MaintenanceWindowResponse, error)
+	// ListVenueMaintenance lists the maintenance of every court of a venue that has not ended yet
+	ListVenueMaintenance(ctx context.Context, venueID uuid.UUID) ([]responses.MaintenanceWindowResponse, error)
 }
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrValidation = errors.New("validation error")
+
+	ErrCourtNotFound = errors.New("court not found")
+
+	ErrCourtInUse = errors.New("court has active bookings")
+
+	ErrMaintenanceNotFound = errors.New("maintenance window not found")
+)
@@ -2,6 +2,8 @@ package court
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,20 +16,29 @@ import (
 )
 
 type useCase struct {
-	courtRepo   interfaces.CourtRepository
-	venueRepo   interfaces.VenueRepository
-	bookingRepo interfaces.BookingRepository
+	courtRepo       interfaces.CourtRepository
+	venueRepo       interfaces.VenueRepository
+	bookingRepo     interfaces.BookingRepository
+	maintenanceRepo interfaces.MaintenanceRepository
+	sessionRepo     interfaces.SessionRepository
+	txManager       interfaces.TransactionManager
 }
 
 func NewCourtUseCase(
 	courtRepo interfaces.CourtRepository,
 	venueRepo interfaces.VenueRepository,
 	bookingRepo interfaces.BookingRepository,
+	maintenanceRepo interfaces.MaintenanceRepository,
+	sessionRepo interfaces.SessionRepository,
+	txManager interfaces.TransactionManager,
 ) UseCase {
 	return &useCase{
-		courtRepo:   courtRepo,
-		venueRepo:   venueRepo,
-		bookingRepo: bookingRepo,
+		courtRepo:       courtRepo,
+		venueRepo:       venueRepo,
+		bookingRepo:     bookingRepo,
+		maintenanceRepo: maintenanceRepo,
+		sessionRepo:     sessionRepo,
+		txManager:       txManager,
 	}
 }
 
@@ -35,7 +46,7 @@ func (uc *useCase) CreateCourt(ctx context.Context, req requests.CreateCourtRequ
 	// Validate venue exists and is active
 	venueID, err := uuid.Parse(req.VenueID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid venue ID: %w", err)
+		return nil, fmt.Errorf("%w: invalid venue ID", ErrValidation)
 	}
 
 	venue, err := uc.venueRepo.GetByID(ctx, venueID)
@@ -44,7 +55,7 @@ func (uc *useCase) CreateCourt(ctx context.Context, req requests.CreateCourtRequ
 	}
 
 	if venue.Status != models.VenueStatusActive {
-		return nil, fmt.Errorf("cannot create court for inactive venue")
+		return nil, fmt.Errorf("%w: cannot create court for inactive venue", ErrValidation)
 	}
 
 	court := &models.Court{
@@ -72,18 +83,18 @@ func (uc *useCase) CreateCourt(ctx context.Context, req requests.CreateCourtRequ
 }
 
 func (uc *useCase) GetCourt(ctx context.Context, id uuid.UUID) (*responses.CourtResponse, error) {
-	court, err := uc.courtRepo.GetByID(ctx, id)
+	court, err := uc.getCourt(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("court not found: %w", err)
+		return nil, err
 	}
 
 	return uc.toCourtResponse(court), nil
 }
 
 func (uc *useCase) UpdateCourt(ctx context.Context, id uuid.UUID, req requests.UpdateCourtRequest) (*responses.CourtResponse, error) {
-	court, err := uc.courtRepo.GetByID(ctx, id)
+	court, err := uc.getCourt(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("court not found: %w", err)
+		return nil, err
 	}
 
 	if req.Name != "" {
@@ -95,9 +106,6 @@ func (uc *useCase) UpdateCourt(ctx context.Context, id uuid.UUID, req requests.U
 	if req.PricePerHour > 0 {
 		court.PricePerHour = req.PricePerHour
 	}
-	if req.Status != "" {
-		court.Status = models.CourtStatus(req.Status)
-	}
 
 	court.UpdatedAt = time.Now()
 
@@ -108,25 +116,34 @@ func (uc *useCase) UpdateCourt(ctx context.Context, id uuid.UUID, req requests.U
 	return uc.toCourtResponse(court), nil
 }
 
+// DeleteCourt removes a court that nobody has reserved from today on, in the venue's time zone.
+// The court row is locked so a booking cannot be made while the check runs.
 func (uc *useCase) DeleteCourt(ctx context.Context, id uuid.UUID) error {
-	// Check if court has any future bookings
-	now := time.Now()
-	bookings, err := uc.bookingRepo.GetCourtBookings(ctx, id, now)
-	if err != nil {
-		return fmt.Errorf("failed to check court bookings: %w", err)
+	if _, err := uc.getCourt(ctx, id); err != nil {
+		return err
 	}
 
-	for _, booking := range bookings {
-		if booking.Status != models.BookingStatusCancelled {
-			return fmt.Errorf("cannot delete court with active bookings")
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.courtRepo.LockCourts(ctx, []uuid.UUID{id}); err != nil {
+			return fmt.Errorf("failed to lock court: %w", err)
 		}
-	}
 
-	if err := uc.courtRepo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete court: %w", err)
-	}
+		now := time.Now().In(models.BookingTimeZone)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		reserved, err := uc.courtRepo.HasUpcomingReservations(ctx, id, today)
+		if err != nil {
+			return fmt.Errorf("failed to check court bookings: %w", err)
+		}
+		if reserved {
+			return fmt.Errorf("%w: cannot delete court with upcoming bookings or sessions", ErrCourtInUse)
+		}
 
-	return nil
+		if err := uc.courtRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete court: %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (uc *useCase) ListCourts(ctx context.Context, req requests.ListCourtsRequest) (*responses.CourtListResponse, error) {
@@ -135,11 +152,27 @@ func (uc *useCase) ListCourts(ctx context.Context, req requests.ListCourtsReques
 	if req.VenueID != "" {
 		venueID, err := uuid.Parse(req.VenueID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid venue ID: %w", err)
+			return nil, fmt.Errorf("%w: invalid venue ID", ErrValidation)
 		}
 		filters["venue_id"] = venueID
 	}
 
+	if req.OwnerID != "" {
+		ownerID, err := uuid.Parse(req.OwnerID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid owner ID", ErrValidation)
+		}
+		filters["owner_id"] = ownerID
+	}
+
+	if req.Status != "" && !isValidCourtStatus(req.Status) {
+		return nil, fmt.Errorf("%w: invalid court status: %s", ErrValidation, req.Status)
+	}
+
+	if req.PriceMin > 0 && req.PriceMax > 0 && req.PriceMin > req.PriceMax {
+		return nil, fmt.Errorf("%w: price_min must not exceed price_max", ErrValidation)
+	}
+
 	if req.Status != "" {
 		filters["status"] = models.CourtStatus(req.Status)
 	}
@@ -216,34 +249,218 @@ func (uc *useCase) GetVenueCourts(ctx context.Context, venueID uuid.UUID) ([]res
 func (uc *useCase) UpdateCourtStatus(ctx context.Context, id uuid.UUID, status string) error {
 
 	if !isValidCourtStatus(status) {
-		return fmt.Errorf("invalid court status: %s", status)
+		return fmt.Errorf("%w: invalid court status: %s", ErrValidation, status)
+	}
+
+	if _, err := uc.getCourt(ctx, id); err != nil {
+		return err
 	}
 
+	// The live status is derived from bookings and maintenance windows every minute,
+	// so a maintenance flag set by hand would not last
 	newStatus := models.CourtStatus(status)
 	if newStatus == models.CourtStatusMaintenance {
-		// Check for future bookings if setting to maintenance
-		now := time.Now()
-		bookings, err := uc.bookingRepo.GetCourtBookings(ctx, id, now)
-		if err != nil {
-			return fmt.Errorf("failed to check court bookings: %w", err)
+		return fmt.Errorf("%w: schedule a maintenance window to take the court out of use", ErrValidation)
+	}
+
+	if err := uc.courtRepo.UpdateStatus(ctx, id, newStatus); err != nil {
+		return fmt.Errorf("failed to update court status: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) IsOwner(ctx context.Context, courtID uuid.UUID, userID uuid.UUID) (bool, error) {
+	court, err := uc.getCourt(ctx, courtID)
+	if err != nil {
+		return false, err
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	return venue.OwnerID == userID, nil
+}
+
+// ScheduleMaintenance blocks a court for a period. It is refused while pending or confirmed
+// bookings or open sessions overlap the period, so players are never left holding a court that
+// cannot be used. The court row is locked while checking, as bookings and sessions do, so none
+// can take the court between the check and the insert.
+func (uc *useCase) ScheduleMaintenance(ctx context.Context, courtID uuid.UUID, userID uuid.UUID, req requests.CreateMaintenanceWindowRequest) (*responses.MaintenanceWindowResponse, error) {
+	court, err := uc.getCourt(ctx, courtID)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, err := parseMaintenanceTime(req.StartsAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid start: %v", ErrValidation, err)
+	}
+	endsAt, err := parseMaintenanceTime(req.EndsAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid end: %v", ErrValidation, err)
+	}
+
+	window := &models.MaintenanceWindow{
+		ID:        uuid.New(),
+		CourtID:   courtID,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Reason:    req.Reason,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+		CourtName: court.Name,
+	}
+	if err := window.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.courtRepo.LockCourts(ctx, []uuid.UUID{courtID}); err != nil {
+			return fmt.Errorf("failed to lock court: %w", err)
+		}
+
+		if err := uc.checkMaintenanceConflicts(ctx, court.VenueID, courtID, startsAt, endsAt); err != nil {
+			return err
 		}
 
-		for _, booking := range bookings {
-			if booking.Status == models.BookingStatusConfirmed {
-				return fmt.Errorf("cannot set court to maintenance: has confirmed future bookings")
-			}
+		if err := uc.maintenanceRepo.Create(ctx, window); err != nil {
+			return fmt.Errorf("failed to schedule maintenance: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := uc.courtRepo.UpdateStatus(ctx, id, newStatus); err != nil {
-		return fmt.Errorf("failed to update court status: %w", err)
+	return window.ToResponse(), nil
+}
+
+// checkMaintenanceConflicts refuses a maintenance period that overlaps an active booking or an
+// open session on the court
+func (uc *useCase) checkMaintenanceConflicts(ctx context.Context, venueID, courtID uuid.UUID, startsAt, endsAt time.Time) error {
+	firstDay := startsAt.In(models.BookingTimeZone)
+	lastDay := endsAt.In(models.BookingTimeZone)
+	first := time.Date(firstDay.Year(), firstDay.Month(), firstDay.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day(), 0, 0, 0, 0, time.UTC)
+
+	bookings, err := uc.bookingRepo.GetVenueBookings(ctx, venueID, first, last)
+	if err != nil {
+		return fmt.Errorf("failed to check court bookings: %w", err)
+	}
+
+	conflicts := 0
+	for _, booking := range bookings {
+		if booking.CourtID != courtID || booking.Status == models.BookingStatusCancelled {
+			continue
+		}
+		if booking.StartsAt().Before(endsAt) && startsAt.Before(booking.EndsAt()) {
+			conflicts++
+		}
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%w: %d bookings overlap the maintenance window", ErrCourtInUse, conflicts)
+	}
+
+	sessions, err := uc.sessionRepo.GetCourtSessionsBetween(ctx, courtID, first, last)
+	if err != nil {
+		return fmt.Errorf("failed to check court sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		sessionStart, sessionEnd := sessionPeriod(session)
+		if sessionStart.Before(endsAt) && startsAt.Before(sessionEnd) {
+			return fmt.Errorf("%w: session %q overlaps the maintenance window", ErrCourtInUse, session.Title)
+		}
+	}
+
+	return nil
+}
+
+// sessionPeriod returns the moments a session begins and ends; sessions never run past midnight
+func sessionPeriod(session models.Session) (time.Time, time.Time) {
+	start := time.Date(
+		session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
+		session.StartTime.Hour(), session.StartTime.Minute(), 0, 0,
+		models.BookingTimeZone)
+	end := time.Date(
+		session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
+		session.EndTime.Hour(), session.EndTime.Minute(), 0, 0,
+		models.BookingTimeZone)
+	return start, end
+}
+
+func (uc *useCase) CancelMaintenance(ctx context.Context, courtID uuid.UUID, windowID uuid.UUID) error {
+	if err := uc.maintenanceRepo.Delete(ctx, courtID, windowID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMaintenanceNotFound
+		}
+		return fmt.Errorf("failed to cancel maintenance: %w", err)
 	}
 
 	return nil
 }
 
+func (uc *useCase) ListMaintenance(ctx context.Context, courtID uuid.UUID) ([]responses.MaintenanceWindowResponse, error) {
+	court, err := uc.getCourt(ctx, courtID)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := uc.maintenanceRepo.ListUpcoming(ctx, court.VenueID, &courtID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maintenance: %w", err)
+	}
+
+	return toMaintenanceResponses(windows), nil
+}
+
+func (uc *useCase) ListVenueMaintenance(ctx context.Context, venueID uuid.UUID) ([]responses.MaintenanceWindowResponse, error) {
+	windows, err := uc.maintenanceRepo.ListUpcoming(ctx, venueID, nil, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maintenance: %w", err)
+	}
+
+	return toMaintenanceResponses(windows), nil
+}
+
 // Helper methods
 
+func toMaintenanceResponses(windows []models.MaintenanceWindow) []responses.MaintenanceWindowResponse {
+	result := make([]responses.MaintenanceWindowResponse, len(windows))
+	for i := range windows {
+		result[i] = *windows[i].ToResponse()
+	}
+	return result
+}
+
+// parseMaintenanceTime accepts an RFC 3339 timestamp or a local "2006-01-02 15:04" in the booking time zone
+func parseMaintenanceTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04", value, models.BookingTimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 or YYYY-MM-DD HH:MM")
+	}
+	return t, nil
+}
+
+func (uc *useCase) getCourt(ctx context.Context, id uuid.UUID) (*models.Court, error) {
+	court, err := uc.courtRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCourtNotFound
+		}
+		return nil, fmt.Errorf("failed to get court: %w", err)
+	}
+
+	return court, nil
+}
+
 func (uc *useCase) toCourtResponse(court *models.Court) *responses.CourtResponse {
 	description := ""
 	if court.Description != "" {
@@ -251,11 +468,14 @@ func (uc *useCase) toCourtResponse(court *models.Court) *responses.CourtResponse
 	}
 
 	return &responses.CourtResponse{
-		ID:           court.ID.String(),
-		Name:         court.Name,
-		Description:  description,
-		PricePerHour: court.PricePerHour,
-		Status:       string(court.Status),
+		ID:            court.ID.String(),
+		VenueID:       court.VenueID.String(),
+		VenueName:     court.VenueName,
+		VenueLocation: court.VenueLocation,
+		Name:          court.Name,
+		Description:   description,
+		PricePerHour:  court.PricePerHour,
+		Status:        string(court.Status),
 	}
 }
 
@@ -0,0 +1,35 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	CreateRule(ctx context.Context, venueID uuid.UUID, req requests.CreatePricingRuleRequest) (*responses.PricingRuleResponse, error)
+	ListRules(ctx context.Context, venueID uuid.UUID) ([]responses.PricingRuleResponse, error)
+	DeleteRule(ctx context.Context, venueID uuid.UUID, ruleID uuid.UUID) error
+	CreateHoliday(ctx context.Context, venueID uuid.UUID, req requests.CreateHolidayRequest) (*responses.HolidayResponse, error)
+	ListHolidays(ctx context.Context, venueID uuid.UUID) ([]responses.HolidayResponse, error)
+	DeleteHoliday(ctx context.Context, venueID uuid.UUID, holidayID uuid.UUID) error
+}
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrValidation = errors.New("validation error")
+
+	ErrVenueNotFound = errors.New("venue not found")
+
+	ErrRuleNotFound = errors.New("pricing rule not found")
+
+	ErrHolidayNotFound = errors.New("holiday not found")
+
+	ErrDuplicateHoliday = interfaces.ErrDuplicateHoliday
+)
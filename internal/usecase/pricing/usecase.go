@@ -0,0 +1,191 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type useCase struct {
+	pricingRepo interfaces.PricingRepository
+	venueRepo   interfaces.VenueRepository
+	courtRepo   interfaces.CourtRepository
+}
+
+func NewPricingUseCase(
+	pricingRepo interfaces.PricingRepository,
+	venueRepo interfaces.VenueRepository,
+	courtRepo interfaces.CourtRepository,
+) UseCase {
+	return &useCase{
+		pricingRepo: pricingRepo,
+		venueRepo:   venueRepo,
+		courtRepo:   courtRepo,
+	}
+}
+
+func (uc *useCase) CreateRule(ctx context.Context, venueID uuid.UUID, req requests.CreatePricingRuleRequest) (*responses.PricingRuleResponse, error) {
+	if _, err := uc.venueRepo.GetByID(ctx, venueID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	weekdays, err := models.ParseWeekdays(req.Weekdays)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	startTime, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid start time format", ErrValidation)
+	}
+	endTime, err := time.Parse("15:04", req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid end time format", ErrValidation)
+	}
+
+	rule := &models.PricingRule{
+		ID:           uuid.New(),
+		VenueID:      venueID,
+		Name:         req.Name,
+		Weekdays:     weekdays,
+		HolidaysOnly: req.HolidaysOnly,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		PricePerHour: req.PricePerHour,
+		Priority:     req.Priority,
+		CreatedAt:    time.Now(),
+	}
+
+	if req.CourtID != nil {
+		courtID, err := uuid.Parse(*req.CourtID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid court ID", ErrValidation)
+		}
+
+		court, err := uc.courtRepo.GetByID(ctx, courtID)
+		if err != nil || court.VenueID != venueID {
+			return nil, fmt.Errorf("%w: court does not belong to this venue", ErrValidation)
+		}
+		rule.CourtID = &courtID
+	}
+
+	if req.StartDate != nil {
+		startDate, err := time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid start date format", ErrValidation)
+		}
+		rule.StartDate = &startDate
+	}
+	if req.EndDate != nil {
+		endDate, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid end date format", ErrValidation)
+		}
+		rule.EndDate = &endDate
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	if err := uc.pricingRepo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create pricing rule: %w", err)
+	}
+
+	return rule.ToResponse(), nil
+}
+
+func (uc *useCase) ListRules(ctx context.Context, venueID uuid.UUID) ([]responses.PricingRuleResponse, error) {
+	rules, err := uc.pricingRepo.ListRules(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing rules: %w", err)
+	}
+
+	result := make([]responses.PricingRuleResponse, len(rules))
+	for i, rule := range rules {
+		result[i] = *rule.ToResponse()
+	}
+
+	return result, nil
+}
+
+func (uc *useCase) DeleteRule(ctx context.Context, venueID uuid.UUID, ruleID uuid.UUID) error {
+	if err := uc.pricingRepo.DeleteRule(ctx, venueID, ruleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRuleNotFound
+		}
+		return fmt.Errorf("failed to delete pricing rule: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) CreateHoliday(ctx context.Context, venueID uuid.UUID, req requests.CreateHolidayRequest) (*responses.HolidayResponse, error) {
+	if _, err := uc.venueRepo.GetByID(ctx, venueID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date format", ErrValidation)
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrValidation)
+	}
+
+	holiday := &models.Holiday{
+		ID:        uuid.New(),
+		VenueID:   venueID,
+		Date:      date,
+		Name:      req.Name,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.pricingRepo.CreateHoliday(ctx, holiday); err != nil {
+		if errors.Is(err, interfaces.ErrDuplicateHoliday) {
+			return nil, ErrDuplicateHoliday
+		}
+		return nil, fmt.Errorf("failed to create holiday: %w", err)
+	}
+
+	return holiday.ToResponse(), nil
+}
+
+// ListHolidays lists the venue's holidays from today onwards
+func (uc *useCase) ListHolidays(ctx context.Context, venueID uuid.UUID) ([]responses.HolidayResponse, error) {
+	today := time.Now().In(models.BookingTimeZone).Format("2006-01-02")
+	from, _ := time.Parse("2006-01-02", today)
+
+	holidays, err := uc.pricingRepo.ListHolidays(ctx, venueID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holidays: %w", err)
+	}
+
+	result := make([]responses.HolidayResponse, len(holidays))
+	for i, holiday := range holidays {
+		result[i] = *holiday.ToResponse()
+	}
+
+	return result, nil
+}
+
+func (uc *useCase) DeleteHoliday(ctx context.Context, venueID uuid.UUID, holidayID uuid.UUID) error {
+	if err := uc.pricingRepo.DeleteHoliday(ctx, venueID, holidayID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrHolidayNotFound
+		}
+		return fmt.Errorf("failed to delete holiday: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	CreatePromotion(ctx context.Context, userID uuid.UUID, req requests.CreatePromotionRequest) (*responses.PromotionResponse, error)
+	// ListPromotions lists a venue's promotions, or every promotion for an admin when venueID is nil
+	ListPromotions(ctx context.Context, userID uuid.UUID, venueID *uuid.UUID) ([]responses.PromotionResponse, error)
+	DeactivatePromotion(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrValidation = errors.New("validation error")
+
+	ErrVenueNotFound = errors.New("venue not found")
+
+	ErrPromotionNotFound = errors.New("promotion not found")
+
+	ErrDuplicateCode = interfaces.ErrDuplicatePromotionCode
+)
@@ -0,0 +1,188 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type useCase struct {
+	promotionRepo interfaces.PromotionRepository
+	venueRepo     interfaces.VenueRepository
+	courtRepo     interfaces.CourtRepository
+	userRepo      interfaces.UserRepository
+}
+
+func NewPromotionUseCase(
+	promotionRepo interfaces.PromotionRepository,
+	venueRepo interfaces.VenueRepository,
+	courtRepo interfaces.CourtRepository,
+	userRepo interfaces.UserRepository,
+) UseCase {
+	return &useCase{
+		promotionRepo: promotionRepo,
+		venueRepo:     venueRepo,
+		courtRepo:     courtRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func (uc *useCase) CreatePromotion(ctx context.Context, userID uuid.UUID, req requests.CreatePromotionRequest) (*responses.PromotionResponse, error) {
+	var venueID *uuid.UUID
+	if req.VenueID != nil {
+		id, err := uuid.Parse(*req.VenueID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid venue ID", ErrValidation)
+		}
+		venueID = &id
+	}
+
+	if err := uc.authorize(ctx, userID, venueID); err != nil {
+		return nil, err
+	}
+
+	startsAt, err := parsePromotionTime(req.StartsAt, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid start: %v", ErrValidation, err)
+	}
+	endsAt, err := parsePromotionTime(req.EndsAt, true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid end: %v", ErrValidation, err)
+	}
+
+	now := time.Now()
+	promotion := &models.Promotion{
+		ID:             uuid.New(),
+		Code:           models.NormalizePromotionCode(req.Code),
+		Description:    req.Description,
+		VenueID:        venueID,
+		DiscountType:   models.DiscountType(req.DiscountType),
+		DiscountValue:  req.DiscountValue,
+		MinSpend:       req.MinSpend,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		Active:         true,
+		CreatedBy:      userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	for _, rawID := range req.CourtIDs {
+		courtID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid court ID", ErrValidation)
+		}
+
+		court, err := uc.courtRepo.GetByID(ctx, courtID)
+		if err != nil || venueID == nil || court.VenueID != *venueID {
+			return nil, fmt.Errorf("%w: court %s does not belong to this venue", ErrValidation, courtID)
+		}
+		promotion.CourtIDs = append(promotion.CourtIDs, courtID)
+	}
+
+	if err := promotion.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	if err := uc.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion.ToResponse(), nil
+}
+
+func (uc *useCase) ListPromotions(ctx context.Context, userID uuid.UUID, venueID *uuid.UUID) ([]responses.PromotionResponse, error) {
+	if err := uc.authorize(ctx, userID, venueID); err != nil {
+		return nil, err
+	}
+
+	promotions, err := uc.promotionRepo.List(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	result := make([]responses.PromotionResponse, len(promotions))
+	for i := range promotions {
+		result[i] = *promotions[i].ToResponse()
+	}
+
+	return result, nil
+}
+
+// DeactivatePromotion stops a code from being redeemed. Bookings that already used it keep their discount.
+func (uc *useCase) DeactivatePromotion(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	promotion, err := uc.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPromotionNotFound
+		}
+		return fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	if err := uc.authorize(ctx, userID, promotion.VenueID); err != nil {
+		return err
+	}
+
+	if err := uc.promotionRepo.Deactivate(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPromotionNotFound
+		}
+		return fmt.Errorf("failed to deactivate promotion: %w", err)
+	}
+
+	return nil
+}
+
+// authorize checks that the user is an admin, or owns the venue of a venue promotion.
+// Promotions that apply across every venue are managed by admins only.
+func (uc *useCase) authorize(ctx context.Context, userID uuid.UUID, venueID *uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if user.Role == string(models.UserRoleAdmin) {
+		return nil
+	}
+
+	if venueID == nil {
+		return fmt.Errorf("%w: only admins can manage promotions across all venues", ErrUnauthorized)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, *venueID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+	if venue.OwnerID != userID {
+		return fmt.Errorf("%w: only the venue owner can manage its promotions", ErrUnauthorized)
+	}
+
+	return nil
+}
+
+// parsePromotionTime accepts an RFC 3339 timestamp or a plain date in the booking time zone.
+// A plain end date covers that whole day.
+func parsePromotionTime(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, models.BookingTimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 or YYYY-MM-DD")
+	}
+	if end {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date, nil
+}
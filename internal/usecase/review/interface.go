@@ -0,0 +1,33 @@
+package review
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	CreateReview(ctx context.Context, reviewerID uuid.UUID, req requests.CreatePlayerReviewRequest) (*responses.PlayerReviewResponse, error)
+	GetReceivedReviews(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.PlayerReviewListResponse, error)
+	GetGivenReviews(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.PlayerReviewListResponse, error)
+	GetReviewablePlayers(ctx context.Context, sessionID, userID uuid.UUID) ([]responses.ReviewablePlayerResponse, error)
+}
+
+var (
+	ErrValidation = errors.New("validation error")
+
+	ErrSessionNotFound = errors.New("session not found")
+
+	ErrSessionNotCompleted = errors.New("session is not completed")
+
+	ErrSelfReview = errors.New("cannot review yourself")
+
+	ErrNotCoPlayer = errors.New("reviewer and player did not play together in this session")
+
+	ErrAlreadyReviewed = interfaces.ErrDuplicateReview
+)
@@ -0,0 +1,258 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type useCase struct {
+	reviewRepo  interfaces.PlayerReviewRepository
+	sessionRepo interfaces.SessionRepository
+}
+
+func NewReviewUseCase(reviewRepo interfaces.PlayerReviewRepository, sessionRepo interfaces.SessionRepository) UseCase {
+	return &useCase{
+		reviewRepo:  reviewRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *useCase) CreateReview(ctx context.Context, reviewerID uuid.UUID, req requests.CreatePlayerReviewRequest) (*responses.PlayerReviewResponse, error) {
+	sessionID, err := uuid.Parse(req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid session ID", ErrValidation)
+	}
+
+	reviewedID, err := uuid.Parse(req.ReviewedID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid reviewed player ID", ErrValidation)
+	}
+
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", ErrValidation)
+	}
+
+	if reviewerID == reviewedID {
+		return nil, ErrSelfReview
+	}
+
+	session, err := uc.getCompletedSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed := confirmedParticipants(session.Participants)
+	if _, ok := confirmed[reviewerID]; !ok {
+		return nil, ErrNotCoPlayer
+	}
+	if _, ok := confirmed[reviewedID]; !ok {
+		return nil, ErrNotCoPlayer
+	}
+
+	exists, err := uc.reviewRepo.Exists(ctx, reviewerID, reviewedID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing review: %w", err)
+	}
+	if exists {
+		return nil, ErrAlreadyReviewed
+	}
+
+	review := &models.PlayerReview{
+		ID:         uuid.New(),
+		ReviewerID: reviewerID,
+		ReviewedID: reviewedID,
+		SessionID:  sessionID,
+		Rating:     req.Rating,
+		CreatedAt:  time.Now(),
+	}
+	if req.Comment != "" {
+		review.Comment = &req.Comment
+	}
+
+	if err := uc.reviewRepo.Create(ctx, review); err != nil {
+		if errors.Is(err, interfaces.ErrDuplicateReview) {
+			return nil, ErrAlreadyReviewed
+		}
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	created, err := uc.reviewRepo.GetByID(ctx, review.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created review: %w", err)
+	}
+
+	response := toPlayerReviewResponse(created)
+	return &response, nil
+}
+
+func (uc *useCase) GetReceivedReviews(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.PlayerReviewListResponse, error) {
+	limit, offset = normalizePagination(limit, offset)
+
+	reviews, err := uc.reviewRepo.GetReceived(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get received reviews: %w", err)
+	}
+
+	summary, err := uc.reviewRepo.GetRatingSummary(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating summary: %w", err)
+	}
+
+	return &responses.PlayerReviewListResponse{
+		Reviews:       toPlayerReviewResponses(reviews),
+		AverageRating: summary.AverageRating,
+		Total:         summary.TotalReviews,
+		Limit:         limit,
+		Offset:        offset,
+	}, nil
+}
+
+func (uc *useCase) GetGivenReviews(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.PlayerReviewListResponse, error) {
+	limit, offset = normalizePagination(limit, offset)
+
+	reviews, err := uc.reviewRepo.GetGiven(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get given reviews: %w", err)
+	}
+
+	total, err := uc.reviewRepo.CountGiven(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count given reviews: %w", err)
+	}
+
+	return &responses.PlayerReviewListResponse{
+		Reviews: toPlayerReviewResponses(reviews),
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+func (uc *useCase) GetReviewablePlayers(ctx context.Context, sessionID, userID uuid.UUID) ([]responses.ReviewablePlayerResponse, error) {
+	session, err := uc.getCompletedSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := uc.sessionRepo.GetParticipants(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	confirmed := confirmedParticipants(participants)
+	if _, ok := confirmed[userID]; !ok {
+		return nil, ErrNotCoPlayer
+	}
+
+	reviewedIDs, err := uc.reviewRepo.GetReviewedIDs(ctx, userID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewed players: %w", err)
+	}
+
+	reviewed := make(map[uuid.UUID]struct{}, len(reviewedIDs))
+	for _, id := range reviewedIDs {
+		reviewed[id] = struct{}{}
+	}
+
+	players := []responses.ReviewablePlayerResponse{}
+	for _, participant := range confirmed {
+		if participant.UserID == userID {
+			continue
+		}
+		if _, ok := reviewed[participant.UserID]; ok {
+			continue
+		}
+		players = append(players, responses.ReviewablePlayerResponse{
+			UserID:      participant.UserID.String(),
+			Name:        participant.UserName,
+			PlayerLevel: string(participant.PlayerLevel),
+		})
+	}
+
+	return players, nil
+}
+
+// Helper functions
+
+func (uc *useCase) getCompletedSession(ctx context.Context, sessionID uuid.UUID) (*models.SessionDetail, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.Status != models.SessionStatusCompleted {
+		return nil, ErrSessionNotCompleted
+	}
+
+	return session, nil
+}
+
+func confirmedParticipants(participants []models.SessionParticipant) map[uuid.UUID]models.SessionParticipant {
+	confirmed := make(map[uuid.UUID]models.SessionParticipant, len(participants))
+	for _, participant := range participants {
+		if participant.Status == models.ParticipantStatusConfirmed {
+			confirmed[participant.UserID] = participant
+		}
+	}
+	return confirmed
+}
+
+func normalizePagination(limit, offset int) (int, int) {
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func toPlayerReviewResponses(reviews []models.PlayerReview) []responses.PlayerReviewResponse {
+	reviewResponses := make([]responses.PlayerReviewResponse, len(reviews))
+	for i := range reviews {
+		reviewResponses[i] = toPlayerReviewResponse(&reviews[i])
+	}
+	return reviewResponses
+}
+
+func toPlayerReviewResponse(review *models.PlayerReview) responses.PlayerReviewResponse {
+	comment := ""
+	if review.Comment != nil {
+		comment = *review.Comment
+	}
+
+	return responses.PlayerReviewResponse{
+		ID:           review.ID.String(),
+		SessionID:    review.SessionID.String(),
+		SessionTitle: review.SessionTitle,
+		SessionDate:  review.SessionDate.Format("2006-01-02"),
+		Rating:       review.Rating,
+		Comment:      comment,
+		CreatedAt:    review.CreatedAt.Format(time.RFC3339),
+		Reviewer: responses.PlayerReviewUserResponse{
+			ID:        review.ReviewerID.String(),
+			FirstName: review.ReviewerFirstName,
+			LastName:  review.ReviewerLastName,
+			AvatarURL: review.ReviewerAvatarURL,
+		},
+		Reviewed: responses.PlayerReviewUserResponse{
+			ID:        review.ReviewedID.String(),
+			FirstName: review.ReviewedFirstName,
+			LastName:  review.ReviewedLastName,
+			AvatarURL: review.ReviewedAvatarURL,
+		},
+	}
+}
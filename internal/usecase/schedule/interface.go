@@ -0,0 +1,32 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	// CreateOverride stores the override and lists the active bookings it no longer allows
+	CreateOverride(ctx context.Context, venueID uuid.UUID, userID uuid.UUID, req requests.CreateScheduleOverrideRequest) (*responses.ScheduleOverrideResponse, error)
+	ListOverrides(ctx context.Context, venueID uuid.UUID) ([]responses.ScheduleOverrideResponse, error)
+	GetAffectedBookings(ctx context.Context, venueID uuid.UUID, overrideID uuid.UUID) ([]responses.BookingResponse, error)
+	DeleteOverride(ctx context.Context, venueID uuid.UUID, overrideID uuid.UUID) error
+}
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrValidation = errors.New("validation error")
+
+	ErrVenueNotFound = errors.New("venue not found")
+
+	ErrOverrideNotFound = errors.New("schedule override not found")
+
+	ErrDuplicateOverride = interfaces.ErrDuplicateScheduleOverride
+)
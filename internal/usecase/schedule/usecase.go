@@ -0,0 +1,171 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type useCase struct {
+	scheduleRepo interfaces.ScheduleRepository
+	venueRepo    interfaces.VenueRepository
+	courtRepo    interfaces.CourtRepository
+	bookingRepo  interfaces.BookingRepository
+}
+
+func NewScheduleUseCase(
+	scheduleRepo interfaces.ScheduleRepository,
+	venueRepo interfaces.VenueRepository,
+	courtRepo interfaces.CourtRepository,
+	bookingRepo interfaces.BookingRepository,
+) UseCase {
+	return &useCase{
+		scheduleRepo: scheduleRepo,
+		venueRepo:    venueRepo,
+		courtRepo:    courtRepo,
+		bookingRepo:  bookingRepo,
+	}
+}
+
+func (uc *useCase) CreateOverride(ctx context.Context, venueID uuid.UUID, userID uuid.UUID, req requests.CreateScheduleOverrideRequest) (*responses.ScheduleOverrideResponse, error) {
+	if _, err := uc.venueRepo.GetByID(ctx, venueID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date format", ErrValidation)
+	}
+
+	override := &models.ScheduleOverride{
+		ID:        uuid.New(),
+		VenueID:   venueID,
+		Date:      date,
+		Closed:    req.Closed,
+		Reason:    req.Reason,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	}
+
+	if req.StartTime != nil {
+		startTime, err := time.Parse("15:04", *req.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid start time format", ErrValidation)
+		}
+		override.StartTime = &startTime
+	}
+
+	if req.EndTime != nil {
+		endTime, err := time.Parse("15:04", *req.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid end time format", ErrValidation)
+		}
+		override.EndTime = &endTime
+	}
+
+	if req.CourtID != nil {
+		courtID, err := uuid.Parse(*req.CourtID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid court ID", ErrValidation)
+		}
+
+		court, err := uc.courtRepo.GetByID(ctx, courtID)
+		if err != nil || court.VenueID != venueID {
+			return nil, fmt.Errorf("%w: court does not belong to this venue", ErrValidation)
+		}
+		override.CourtID = &courtID
+	}
+
+	if err := override.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	if err := uc.scheduleRepo.CreateOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	affected, err := uc.affectedBookings(ctx, override)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := override.ToResponse()
+	resp.AffectedBookings = affected
+
+	return resp, nil
+}
+
+// ListOverrides lists the venue's overrides from today onwards
+func (uc *useCase) ListOverrides(ctx context.Context, venueID uuid.UUID) ([]responses.ScheduleOverrideResponse, error) {
+	today := time.Now().In(models.BookingTimeZone).Format("2006-01-02")
+	from, _ := time.Parse("2006-01-02", today)
+
+	overrides, err := uc.scheduleRepo.ListOverrides(ctx, venueID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule overrides: %w", err)
+	}
+
+	result := make([]responses.ScheduleOverrideResponse, len(overrides))
+	for i := range overrides {
+		result[i] = *overrides[i].ToResponse()
+	}
+
+	return result, nil
+}
+
+func (uc *useCase) GetAffectedBookings(ctx context.Context, venueID uuid.UUID, overrideID uuid.UUID) ([]responses.BookingResponse, error) {
+	override, err := uc.scheduleRepo.GetOverride(ctx, venueID, overrideID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOverrideNotFound
+		}
+		return nil, fmt.Errorf("failed to get schedule override: %w", err)
+	}
+
+	return uc.affectedBookings(ctx, override)
+}
+
+func (uc *useCase) DeleteOverride(ctx context.Context, venueID uuid.UUID, overrideID uuid.UUID) error {
+	if err := uc.scheduleRepo.DeleteOverride(ctx, venueID, overrideID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOverrideNotFound
+		}
+		return fmt.Errorf("failed to delete schedule override: %w", err)
+	}
+
+	return nil
+}
+
+// affectedBookings lists the pending and confirmed bookings on the override's date that fall
+// outside the hours it allows. They are left in place for the owner to sort out with the players.
+func (uc *useCase) affectedBookings(ctx context.Context, override *models.ScheduleOverride) ([]responses.BookingResponse, error) {
+	bookings, err := uc.bookingRepo.GetVenueBookings(ctx, override.VenueID, override.Date, override.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue bookings: %w", err)
+	}
+
+	affected := []responses.BookingResponse{}
+	for i := range bookings {
+		booking := &bookings[i]
+		if booking.Status == models.BookingStatusCancelled {
+			continue
+		}
+
+		schedule := models.AllDaySchedule()
+		schedule.ApplyOverrides([]models.ScheduleOverride{*override}, booking.CourtID)
+		if schedule.Check(booking.StartTime, booking.EndTime) != nil {
+			affected = append(affected, *booking.ToResponse())
+		}
+	}
+
+	return affected, nil
+}
@@ -17,9 +17,9 @@ type UseCase interface {
 
 	ListSessions(ctx context.Context, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error)
 	SearchSessions(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error)
-	JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) error
+	JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) (*responses.JoinSessionResponse, error)
 	LeaveSession(ctx context.Context, sessionID, userID uuid.UUID) error
-	CancelSession(ctx context.Context, sessionID, hostID uuid.UUID) error
+	CancelSession(ctx context.Context, sessionID, hostID uuid.UUID, scope string) error
 	GetUserSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.SessionResponse, error)
 	ChangeParticipantStatus(ctx context.Context, sessionID, hostID uuid.UUID, req requests.ChangeParticipantStatusRequest) error
 	GetSessionParticipants(ctx context.Context, sessionID uuid.UUID) ([]responses.ParticipantResponse, error)
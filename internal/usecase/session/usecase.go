@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
@@ -20,19 +21,57 @@ var (
 	ErrValidation = errors.New("validation error")
 
 	ErrSessionNotFound = errors.New("session not found")
+
+	ErrCourtUnavailable = errors.New("court is not available")
 )
 
 type useCase struct {
-	sessionRepo interfaces.SessionRepository
-	venueRepo   interfaces.VenueRepository
-	chatRepo    interfaces.ChatRepository
+	sessionRepo     interfaces.SessionRepository
+	venueRepo       interfaces.VenueRepository
+	chatRepo        interfaces.ChatRepository
+	courtRepo       interfaces.CourtRepository
+	bookingRepo     interfaces.BookingRepository
+	pricingRepo     interfaces.PricingRepository
+	scheduleRepo    interfaces.ScheduleRepository
+	maintenanceRepo interfaces.MaintenanceRepository
+	txManager       interfaces.TransactionManager
+
+	// bookingCanceller refunds the bookings of a cancelled session through the payment provider
+	bookingCanceller BookingCanceller
+}
+
+// BookingCanceller cancels and refunds the bookings made for a session
+type BookingCanceller interface {
+	CancelSessionBookings(ctx context.Context, sessionID uuid.UUID) error
+	// SettleRefunds sends the refunds of cancelled bookings to the payment provider; it must
+	// run after the cancellation has committed
+	SettleRefunds(ctx context.Context) error
 }
 
-func NewSessionUseCase(sessionRepo interfaces.SessionRepository, venueRepo interfaces.VenueRepository, chatRepo interfaces.ChatRepository) UseCase {
+func NewSessionUseCase(
+	sessionRepo interfaces.SessionRepository,
+	venueRepo interfaces.VenueRepository,
+	chatRepo interfaces.ChatRepository,
+	courtRepo interfaces.CourtRepository,
+	bookingRepo interfaces.BookingRepository,
+	pricingRepo interfaces.PricingRepository,
+	scheduleRepo interfaces.ScheduleRepository,
+	maintenanceRepo interfaces.MaintenanceRepository,
+	txManager interfaces.TransactionManager,
+	bookingCanceller BookingCanceller,
+) UseCase {
 	return &useCase{
-		sessionRepo: sessionRepo,
-		venueRepo:   venueRepo,
-		chatRepo:    chatRepo,
+		sessionRepo:     sessionRepo,
+		venueRepo:       venueRepo,
+		chatRepo:        chatRepo,
+		courtRepo:       courtRepo,
+		bookingRepo:     bookingRepo,
+		pricingRepo:     pricingRepo,
+		scheduleRepo:    scheduleRepo,
+		maintenanceRepo: maintenanceRepo,
+		txManager:       txManager,
+
+		bookingCanceller: bookingCanceller,
 	}
 }
 
@@ -63,7 +102,20 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		return nil, fmt.Errorf("invalid end time: %w", err)
 	}
 
+	if !startTime.Before(endTime) {
+		return nil, fmt.Errorf("%w: start time must be before end time", ErrValidation)
+	}
+
 	// Parse and validate court IDs
+	courts, err := uc.validateSessionCourts(ctx, venue.ID, req.CourtIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.BookCourts && len(courts) == 0 {
+		return nil, fmt.Errorf("%w: at least one court is required to book courts", ErrValidation)
+	}
+
 	// openRanges := []responses.OpenRangeResponse{}
 
 	// if json.Unmarshal(json.RawMessage(venue.OpenRange.RawMessage), &openRanges) != nil {
@@ -93,42 +145,119 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 	// }
 	// }
 
-	// Create session
-	session := &models.Session{
-		ID:                        uuid.New(),
-		HostID:                    hostID,
-		VenueID:                   uuid.MustParse(req.VenueID),
-		Title:                     req.Title,
-		Description:               &req.Description,
-		SessionDate:               sessionDate,
-		StartTime:                 startTime,
-		EndTime:                   endTime,
-		PlayerLevel:               models.PlayerLevel(req.PlayerLevel),
-		MaxParticipants:           req.MaxParticipants,
-		CostPerPerson:             req.CostPerPerson,
-		AllowCancellation:         req.AllowCancellation,
-		CancellationDeadlineHours: &req.CancellationDeadlineHours,
-		IsPublic:                  req.IsPublic,
-		Status:                    models.SessionStatusOpen,
-		CreatedAt:                 time.Now(),
-		UpdatedAt:                 time.Now(),
+	// A recurring session is stored as one play session per occurrence
+	dates := []time.Time{sessionDate}
+	var series *models.SessionSeries
+	if req.Recurrence != nil {
+		series, err = uc.newSessionSeries(hostID, venue.ID, sessionDate, *req.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		dates = series.Dates()
+	}
+
+	courtIDs := make([]uuid.UUID, len(courts))
+	for i, court := range courts {
+		courtIDs[i] = court.ID
+	}
+
+	var firstSessionID uuid.UUID
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the courts so concurrent sessions and bookings for them queue up behind us
+		if err := uc.courtRepo.LockCourts(ctx, courtIDs); err != nil {
+			return fmt.Errorf("failed to lock courts: %w", err)
+		}
+
+		var seriesID *uuid.UUID
+		if series != nil {
+			if err := uc.sessionRepo.CreateSeries(ctx, series); err != nil {
+				return fmt.Errorf("failed to create session series: %w", err)
+			}
+			seriesID = &series.ID
+		}
+
+		for i, date := range dates {
+			session := &models.Session{
+				ID:                        uuid.New(),
+				HostID:                    hostID,
+				VenueID:                   venue.ID,
+				Title:                     req.Title,
+				Description:               &req.Description,
+				SessionDate:               date,
+				StartTime:                 startTime,
+				EndTime:                   endTime,
+				PlayerLevel:               models.PlayerLevel(req.PlayerLevel),
+				MaxParticipants:           req.MaxParticipants,
+				CostPerPerson:             req.CostPerPerson,
+				AllowCancellation:         req.AllowCancellation,
+				CancellationDeadlineHours: &req.CancellationDeadlineHours,
+				IsPublic:                  req.IsPublic,
+				Status:                    models.SessionStatusOpen,
+				SeriesID:                  seriesID,
+				CreatedAt:                 time.Now(),
+				UpdatedAt:                 time.Now(),
+			}
+
+			if err := uc.createOccurrence(ctx, session, courts, req.BookCourts); err != nil {
+				if series != nil {
+					return fmt.Errorf("session on %s: %w", date.Format("2006-01-02"), err)
+				}
+				return err
+			}
+
+			if i == 0 {
+				firstSessionID = session.ID
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get complete session details
+	sessionDetail, err := uc.sessionRepo.GetByID(ctx, firstSessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session details: %w", err)
+	}
+
+	response := uc.toSessionResponse(sessionDetail)
+	if series != nil {
+		response.Series = toSeriesResponse(series, dates)
+	}
+
+	return response, nil
+}
+
+// createOccurrence stores a single play session with its host, chat and courts,
+// booking the courts for the host when requested. The courts must already be locked.
+func (uc *useCase) createOccurrence(ctx context.Context, session *models.Session, courts []models.Court, bookCourts bool) error {
+	if err := uc.checkCourtSchedule(ctx, session, courts); err != nil {
+		return err
+	}
+
+	for _, court := range courts {
+		if err := uc.checkSessionConflict(ctx, session.SessionDate, session.StartTime, session.EndTime, court.ID); err != nil {
+			return err
+		}
 	}
 
 	if err := uc.sessionRepo.Create(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+		return fmt.Errorf("failed to create session: %w", err)
 	}
 
 	// Add host as confirmed participant
 	participant := &models.SessionParticipant{
 		ID:        uuid.New(),
 		SessionID: session.ID,
-		UserID:    hostID,
+		UserID:    session.HostID,
 		Status:    models.ParticipantStatusConfirmed,
 		JoinedAt:  time.Now(),
 	}
 
 	if err := uc.sessionRepo.AddParticipant(ctx, participant); err != nil {
-		return nil, fmt.Errorf("failed to add host as participant: %w", err)
+		return fmt.Errorf("failed to add host as participant: %w", err)
 	}
 
 	chat := models.Chat{
@@ -138,20 +267,89 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 	}
 
 	if err := uc.chatRepo.CreateChat(ctx, &chat); err != nil {
-		return nil, fmt.Errorf("failed to create chat: %w", err)
+		return fmt.Errorf("failed to create chat: %w", err)
 	}
 
-	if err := uc.chatRepo.AddUserToChat(ctx, hostID, chat.ID); err != nil {
-		return nil, fmt.Errorf("failed to add host to chat: %w", err)
+	if err := uc.chatRepo.AddUserToChat(ctx, session.HostID, chat.ID); err != nil {
+		return fmt.Errorf("failed to add host to chat: %w", err)
 	}
 
-	// Get complete session details
-	sessionDetail, err := uc.sessionRepo.GetByID(ctx, session.ID)
+	// Reserve the selected courts, booking them for the host when requested
+	for _, court := range courts {
+		sessionCourt := &models.SessionCourt{
+			ID:        uuid.New(),
+			SessionID: session.ID,
+			CourtID:   court.ID,
+			CreatedAt: time.Now(),
+		}
+		if err := uc.sessionRepo.AddSessionCourt(ctx, sessionCourt); err != nil {
+			return fmt.Errorf("failed to reserve court: %w", err)
+		}
+
+		if !bookCourts {
+			continue
+		}
+
+		pricing, err := uc.pricingRepo.GetVenuePricing(ctx, session.VenueID, session.SessionDate)
+		if err != nil {
+			return fmt.Errorf("failed to get pricing rules: %w", err)
+		}
+
+		booking := uc.newSessionBooking(session, court, pricing)
+		if err := uc.bookingRepo.Create(ctx, &booking); err != nil {
+			if errors.Is(err, interfaces.ErrBookingConflict) {
+				return fmt.Errorf("%w: court %s cannot be booked: %v", ErrCourtUnavailable, court.Name, err)
+			}
+			return fmt.Errorf("failed to book court: %w", err)
+		}
+
+		history := models.NewBookingStatusHistory(booking.ID, "", booking.Status, models.BookingReasonCreated, &session.HostID)
+		if err := uc.bookingRepo.AddStatusHistory(ctx, history); err != nil {
+			return fmt.Errorf("failed to record booking status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// newSessionSeries builds the recurrence rule of a session starting on its first date
+func (uc *useCase) newSessionSeries(hostID, venueID uuid.UUID, startsOn time.Time, req requests.RecurrenceRequest) (*models.SessionSeries, error) {
+	weekdays, err := models.ParseWeekdays(req.Weekdays)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get session details: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
 	}
 
-	return uc.toSessionResponse(sessionDetail), nil
+	series := &models.SessionSeries{
+		ID:            uuid.New(),
+		HostID:        hostID,
+		VenueID:       venueID,
+		Weekdays:      weekdays,
+		IntervalWeeks: req.IntervalWeeks,
+		StartsOn:      startsOn,
+		CreatedAt:     time.Now(),
+	}
+	if series.IntervalWeeks == 0 {
+		series.IntervalWeeks = 1
+	}
+
+	if req.Until != "" {
+		until, err := time.Parse("2006-01-02", req.Until)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid recurrence end date: %v", ErrValidation, err)
+		}
+		series.UntilDate = &until
+	}
+
+	if req.Count > 0 {
+		count := req.Count
+		series.OccurrenceCount = &count
+	}
+
+	if err := series.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	return series, nil
 }
 
 func (uc *useCase) SearchSessions(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error) {
@@ -173,6 +371,27 @@ func (uc *useCase) SearchSessions(ctx context.Context, query string, filters map
 }
 
 func (uc *useCase) UpdateSession(ctx context.Context, sessionID uuid.UUID, hostID uuid.UUID, req requests.UpdateSessionRequest) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		sessionIDs, err := uc.scopedSessionIDs(ctx, sessionID, models.SeriesScope(req.Scope))
+		if err != nil {
+			return err
+		}
+
+		for _, id := range sessionIDs {
+			if err := uc.sessionRepo.Lock(ctx, id); err != nil {
+				return fmt.Errorf("session not found: %w", err)
+			}
+
+			if err := uc.updateSession(ctx, id, hostID, req); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (uc *useCase) updateSession(ctx context.Context, sessionID uuid.UUID, hostID uuid.UUID, req requests.UpdateSessionRequest) error {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("session not found: %w", err)
@@ -201,6 +420,7 @@ func (uc *useCase) UpdateSession(ctx context.Context, sessionID uuid.UUID, hostI
 		}
 		session.PlayerLevel = models.PlayerLevel(req.PlayerLevel)
 	}
+	previousMaxParticipants := session.MaxParticipants
 	if req.MaxParticipants > 0 {
 		confirmedCount, _ := uc.countParticipantsByStatus(session.Participants)
 		if err := uc.validateParticipantLimit(confirmedCount, req.MaxParticipants); err != nil {
@@ -229,6 +449,11 @@ func (uc *useCase) UpdateSession(ctx context.Context, sessionID uuid.UUID, hostI
 		return fmt.Errorf("failed to update session: %w", err)
 	}
 
+	// Extra spots go to the waitlist first
+	if session.MaxParticipants > previousMaxParticipants {
+		return uc.promoteWaitlist(ctx, session)
+	}
+
 	return nil
 }
 
@@ -240,35 +465,61 @@ func (uc *useCase) validateParticipantLimit(confirmedCount, maxParticipants int)
 	return nil
 }
 
-func (uc *useCase) JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) error {
+func (uc *useCase) JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) (*responses.JoinSessionResponse, error) {
+	var result *responses.JoinSessionResponse
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Hold the session row so two players cannot take the last spot
+		if err := uc.sessionRepo.Lock(ctx, sessionID); err != nil {
+			return fmt.Errorf("session not found: %w", err)
+		}
+
+		var err error
+		result, err = uc.joinSession(ctx, sessionID, userID, req)
+		if err != nil {
+			return err
+		}
+
+		if req.JoinSeries {
+			result.EnrolledSessions, err = uc.enrollInSeries(ctx, sessionID, userID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc *useCase) joinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) (*responses.JoinSessionResponse, error) {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("session not found: %w", err)
+		return nil, fmt.Errorf("session not found: %w", err)
 	}
 
 	if err := uc.canJoinSession(session, userID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if user is already participating
 	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to get participants: %w", err)
+		return nil, fmt.Errorf("failed to get participants: %w", err)
 	}
 
 	if isParticipating, status := uc.isParticipantInSession(participants, userID); isParticipating {
 		if status == models.ParticipantStatusCancelled {
-			return fmt.Errorf("you have previously cancelled participation in this session")
+			return nil, fmt.Errorf("you have previously cancelled participation in this session")
 		}
-		return fmt.Errorf("you are already participating in this session")
+		return nil, fmt.Errorf("you are already participating in this session")
 	}
 
+	// A full session queues the player until a spot is freed
 	confirmedCount, _ := uc.countParticipantsByStatus(participants)
-	if confirmedCount >= session.MaxParticipants {
-		return fmt.Errorf("session is full")
-	}
 	status := models.ParticipantStatusConfirmed
-	if !session.IsPublic {
+	if uc.spotsTaken(session, participants) >= session.MaxParticipants {
+		status = models.ParticipantStatusWaitlisted
+	} else if !session.IsPublic {
 		status = models.ParticipantStatusPending
 	}
 
@@ -281,30 +532,91 @@ func (uc *useCase) JoinSession(ctx context.Context, sessionID, userID uuid.UUID,
 	}
 
 	if err := uc.sessionRepo.AddParticipant(ctx, participant); err != nil {
-		return fmt.Errorf("failed to add participant: %w", err)
+		return nil, fmt.Errorf("failed to add participant: %w", err)
 	}
 
-	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to get chat ID: %w", err)
+	result := &responses.JoinSessionResponse{
+		Status: string(status),
 	}
 
-	if err := uc.chatRepo.AddUserToChat(ctx, userID, chatID); err != nil {
-		return fmt.Errorf("failed to add user to chat: %w", err)
+	// Waitlisted players only join the chat once they are promoted
+	if status == models.ParticipantStatusWaitlisted {
+		position := uc.waitlistPosition(append(participants, *participant), userID)
+		result.WaitlistPosition = &position
+		return result, nil
+	}
+
+	if err := uc.addToSessionChat(ctx, sessionID, userID); err != nil {
+		return nil, err
 	}
 
 	// Update session status if max participants reached
 	if status == models.ParticipantStatusConfirmed && confirmedCount+1 >= session.MaxParticipants {
 		session.Status = models.SessionStatusFull
 		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-			return fmt.Errorf("failed to update session status: %w", err)
+			return nil, fmt.Errorf("failed to update session status: %w", err)
 		}
 	}
 
-	return nil
+	return result, nil
+}
+
+// enrollInSeries joins the player to every later occurrence of the session's series
+// they are not part of yet, waitlisting them where an occurrence is already full.
+// Occurrences they cancelled are skipped rather than rejoined.
+func (uc *useCase) enrollInSeries(ctx context.Context, sessionID, userID uuid.UUID) (int, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("session not found: %w", err)
+	}
+
+	if session.SeriesID == nil {
+		return 0, fmt.Errorf("%w: session is not part of a recurring series", ErrValidation)
+	}
+
+	occurrences, err := uc.sessionRepo.GetSeriesSessions(ctx, *session.SeriesID, session.SessionDate.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get series sessions: %w", err)
+	}
+
+	enrolled := 0
+	for _, occurrence := range occurrences {
+		if err := uc.sessionRepo.Lock(ctx, occurrence.ID); err != nil {
+			return 0, fmt.Errorf("session not found: %w", err)
+		}
+
+		participants, err := uc.sessionRepo.GetParticipants(ctx, occurrence.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get participants: %w", err)
+		}
+
+		// Occurrences the player already takes part in are left as they are. One they
+		// cancelled stays cancelled, as joining it on its own would be refused, but that
+		// must not keep them out of the rest of the series.
+		if joined, _ := uc.isParticipantInSession(participants, userID); joined {
+			continue
+		}
+
+		if _, err := uc.joinSession(ctx, occurrence.ID, userID, requests.JoinSessionRequest{}); err != nil {
+			return 0, fmt.Errorf("failed to join session on %s: %w", occurrence.SessionDate.Format("2006-01-02"), err)
+		}
+		enrolled++
+	}
+
+	return enrolled, nil
 }
 
 func (uc *useCase) LeaveSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.sessionRepo.Lock(ctx, sessionID); err != nil {
+			return fmt.Errorf("session not found: %w", err)
+		}
+
+		return uc.leaveSession(ctx, sessionID, userID)
+	})
+}
+
+func (uc *useCase) leaveSession(ctx context.Context, sessionID, userID uuid.UUID) error {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("session not found: %w", err)
@@ -315,6 +627,25 @@ func (uc *useCase) LeaveSession(ctx context.Context, sessionID, userID uuid.UUID
 		return fmt.Errorf("host cannot leave session, use cancel instead")
 	}
 
+	// Check if user is participating
+	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	isParticipating, currentStatus := uc.isParticipantInSession(participants, userID)
+	if !isParticipating || currentStatus == models.ParticipantStatusCancelled {
+		return fmt.Errorf("user is not participating in this session")
+	}
+
+	// Leaving the waitlist frees no spot, so the cancellation policy does not apply
+	if currentStatus == models.ParticipantStatusWaitlisted {
+		if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, userID, models.ParticipantStatusCancelled); err != nil {
+			return fmt.Errorf("failed to update participant status: %w", err)
+		}
+		return nil
+	}
+
 	// Check cancellation policy
 	if !session.AllowCancellation {
 		return fmt.Errorf("cancellation is not allowed for this session")
@@ -327,42 +658,11 @@ func (uc *useCase) LeaveSession(ctx context.Context, sessionID, userID uuid.UUID
 		}
 	}
 
-	// Check if user is participating
-	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to get participants: %w", err)
-	}
-
-	isParticipating, currentStatus := uc.isParticipantInSession(participants, userID)
-	if !isParticipating {
-		return fmt.Errorf("user is not participating in this session")
-	}
-
 	// Update participant status to cancelled
 	if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, userID, models.ParticipantStatusCancelled); err != nil {
 		return fmt.Errorf("failed to update participant status: %w", err)
 	}
 
-	// // If user was confirmed, try to promote a pending participant
-	// if currentStatus == models.ParticipantStatusConfirmed {
-	// 	for _, p := range participants {
-	// 		if p.Status == models.ParticipantStatusPending {
-	// 			if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, p.UserID, models.ParticipantStatusConfirmed); err != nil {
-	// 				return fmt.Errorf("failed to promote pending participant: %w", err)
-	// 			}
-	// 			return nil
-	// 		}
-	// 	}
-
-	// 	// No pending participants and session was full, update to open
-	// 	if session.Status == models.SessionStatusFull {
-	// 		session.Status = models.SessionStatusOpen
-	// 		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-	// 			return fmt.Errorf("failed to update session status: %w", err)
-	// 		}
-	// 	}
-	// }
-
 	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get chat ID: %w", err)
@@ -372,16 +672,73 @@ func (uc *useCase) LeaveSession(ctx context.Context, sessionID, userID uuid.UUID
 		return fmt.Errorf("failed to remove user from chat: %w", err)
 	}
 
-	if currentStatus == models.ParticipantStatusConfirmed && session.Status == models.SessionStatusFull {
-		session.Status = models.SessionStatusOpen
-		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-			return fmt.Errorf("failed to update session status: %w", err)
+	if currentStatus == models.ParticipantStatusConfirmed {
+		return uc.promoteWaitlist(ctx, session)
+	}
+
+	return nil
+}
+
+func (uc *useCase) CancelSession(ctx context.Context, sessionID, hostID uuid.UUID, scope string) error {
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		sessionIDs, err := uc.scopedSessionIDs(ctx, sessionID, models.SeriesScope(scope))
+		if err != nil {
+			return err
 		}
+
+		for _, id := range sessionIDs {
+			if err := uc.cancelSession(ctx, id, hostID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Refunds the provider does not take now stay pending and are retried by the cron job
+	if err := uc.bookingCanceller.SettleRefunds(ctx); err != nil {
+		log.Printf("failed to settle refunds of session %s: %v", sessionID, err)
 	}
 	return nil
 }
 
-func (uc *useCase) CancelSession(ctx context.Context, sessionID, hostID uuid.UUID) error {
+// scopedSessionIDs resolves the occurrences a change to a session applies to. The
+// following scope adds every later occurrence of its series that is still open for play.
+func (uc *useCase) scopedSessionIDs(ctx context.Context, sessionID uuid.UUID, scope models.SeriesScope) ([]uuid.UUID, error) {
+	switch scope {
+	case "", models.SeriesScopeThis:
+		return []uuid.UUID{sessionID}, nil
+	case models.SeriesScopeFollowing:
+	default:
+		return nil, fmt.Errorf("%w: scope must be %s or %s", ErrValidation, models.SeriesScopeThis, models.SeriesScopeFollowing)
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session not found: %w", err)
+	}
+
+	if session.SeriesID == nil {
+		return []uuid.UUID{sessionID}, nil
+	}
+
+	occurrences, err := uc.sessionRepo.GetSeriesSessions(ctx, *session.SeriesID, session.SessionDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series sessions: %w", err)
+	}
+
+	sessionIDs := []uuid.UUID{sessionID}
+	for _, occurrence := range occurrences {
+		sessionIDs = append(sessionIDs, occurrence.ID)
+	}
+
+	return sessionIDs, nil
+}
+
+func (uc *useCase) cancelSession(ctx context.Context, sessionID, hostID uuid.UUID) error {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("session not found: %w", err)
@@ -404,6 +761,15 @@ func (uc *useCase) CancelSession(ctx context.Context, sessionID, hostID uuid.UUI
 		return fmt.Errorf("failed to update session status: %w", err)
 	}
 
+	// Release reserved courts and cancel the bookings made for them
+	if err := uc.sessionRepo.ReleaseSessionCourts(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to release session courts: %w", err)
+	}
+
+	if err := uc.bookingCanceller.CancelSessionBookings(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to cancel session bookings: %w", err)
+	}
+
 	// Update all active participants to cancelled
 	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
 	if err != nil {
@@ -452,7 +818,6 @@ func (uc *useCase) GetSessionStatus(ctx context.Context, id uuid.UUID, userID uu
 		return nil, fmt.Errorf("session not found")
 	}
 	joinStatus, err := uc.sessionRepo.GetJoinStatus(ctx, userID, id)
-	fmt.Println(joinStatus)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get join status: %w", err)
 	}
@@ -461,6 +826,11 @@ func (uc *useCase) GetSessionStatus(ctx context.Context, id uuid.UUID, userID uu
 	joinStatusStr := string(joinStatus)
 	response.JoinStatus = &joinStatusStr
 
+	if joinStatus == models.JoinStatusWaitlisted {
+		position := uc.waitlistPosition(session.Participants, userID)
+		response.WaitlistPosition = &position
+	}
+
 	return response, nil
 }
 
@@ -524,6 +894,16 @@ func (uc *useCase) GetMyHostedSessions(ctx context.Context, userID uuid.UUID, in
 }
 
 func (uc *useCase) ChangeParticipantStatus(ctx context.Context, sessionID, hostID uuid.UUID, req requests.ChangeParticipantStatusRequest) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.sessionRepo.Lock(ctx, sessionID); err != nil {
+			return fmt.Errorf("session not found: %w", err)
+		}
+
+		return uc.changeParticipantStatus(ctx, sessionID, hostID, req)
+	})
+}
+
+func (uc *useCase) changeParticipantStatus(ctx context.Context, sessionID, hostID uuid.UUID, req requests.ChangeParticipantStatusRequest) error {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("session not found: %w", err)
@@ -579,6 +959,13 @@ func (uc *useCase) ChangeParticipantStatus(ctx context.Context, sessionID, hostI
 		return fmt.Errorf("failed to update participant status: %w", err)
 	}
 
+	// A player let in straight from the waitlist joins the chat now
+	if currentStatus == models.ParticipantStatusWaitlisted && models.ParticipantStatus(req.Status) != models.ParticipantStatusCancelled {
+		if err := uc.addToSessionChat(ctx, sessionID, uuid.MustParse(req.UserID)); err != nil {
+			return err
+		}
+	}
+
 	// Update session status if max participants reached
 	if models.ParticipantStatus(req.Status) == models.ParticipantStatusConfirmed && confirmedCount+1 >= session.MaxParticipants {
 		session.Status = models.SessionStatusFull
@@ -586,12 +973,11 @@ func (uc *useCase) ChangeParticipantStatus(ctx context.Context, sessionID, hostI
 			return fmt.Errorf("failed to update session status: %w", err)
 		}
 	}
+
+	// The spot given up goes to the next waitlisted player
 	if currentStatus == models.ParticipantStatusConfirmed &&
-		models.ParticipantStatus(req.Status) != models.ParticipantStatusConfirmed && session.Status == models.SessionStatusFull {
-		session.Status = models.SessionStatusOpen
-		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-			return fmt.Errorf("failed to update session status: %w", err)
-		}
+		models.ParticipantStatus(req.Status) != models.ParticipantStatusConfirmed {
+		return uc.promoteWaitlist(ctx, session)
 	}
 	return nil
 }
@@ -636,7 +1022,21 @@ func (uc *useCase) toSessionResponse(session *models.SessionDetail) *responses.S
 		}
 	}
 
+	courts := make([]responses.SessionCourtResponse, len(session.Courts))
+	for i, court := range session.Courts {
+		courts[i] = responses.SessionCourtResponse{
+			ID:   court.CourtID.String(),
+			Name: court.CourtName,
+		}
+	}
+
 	confirmedPlayers, pendingPlayers := uc.countParticipantsByStatus(session.Participants)
+	waitlistedPlayers := 0
+	for _, p := range session.Participants {
+		if p.Status == models.ParticipantStatusWaitlisted {
+			waitlistedPlayers++
+		}
+	}
 
 	description := ""
 	if session.Description != nil {
@@ -648,6 +1048,11 @@ func (uc *useCase) toSessionResponse(session *models.SessionDetail) *responses.S
 		cancellationDeadlineHours = session.CancellationDeadlineHours
 	}
 
+	seriesID := ""
+	if session.SeriesID != nil {
+		seriesID = session.SeriesID.String()
+	}
+
 	return &responses.SessionResponse{
 		ID:                        session.ID.String(),
 		Title:                     session.Title,
@@ -668,12 +1073,35 @@ func (uc *useCase) toSessionResponse(session *models.SessionDetail) *responses.S
 		IsPublic:                  session.IsPublic,
 		ConfirmedPlayers:          confirmedPlayers,
 		PendingPlayers:            pendingPlayers,
+		WaitlistedPlayers:         waitlistedPlayers,
 		Participants:              participants,
+		Courts:                    courts,
+		SeriesID:                  seriesID,
 		CreatedAt:                 session.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:                 session.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
+func toSeriesResponse(series *models.SessionSeries, dates []time.Time) *responses.SessionSeriesResponse {
+	occurrenceDates := make([]string, len(dates))
+	for i, date := range dates {
+		occurrenceDates[i] = date.Format("2006-01-02")
+	}
+
+	response := &responses.SessionSeriesResponse{
+		ID:              series.ID.String(),
+		Weekdays:        series.Weekdays.Names(),
+		IntervalWeeks:   series.IntervalWeeks,
+		Count:           series.OccurrenceCount,
+		OccurrenceDates: occurrenceDates,
+	}
+	if series.UntilDate != nil {
+		response.Until = series.UntilDate.Format("2006-01-02")
+	}
+
+	return response
+}
+
 // validateSessionTime validates if the session time is valid including venue hours
 func (uc *useCase) validateSessionTime(sessionDate time.Time, startTime, endTime, venueOpen, venueClose time.Time) error {
 	now := time.Now()
@@ -726,40 +1154,139 @@ func (uc *useCase) validateSessionTime(sessionDate time.Time, startTime, endTime
 	return nil
 }
 
-// checkSessionConflict checks if there's any conflict with existing sessions
-func (uc *useCase) checkSessionConflict(ctx context.Context, sessionDate time.Time, startTime, endTime time.Time, courtID uuid.UUID) error {
-	filters := map[string]interface{}{
-		"date": sessionDate.Format("2006-01-02"),
+// checkCourtSchedule rejects a session on a date the venue or one of its courts is closed,
+// outside the special opening hours set for that date, or while a court is under maintenance
+func (uc *useCase) checkCourtSchedule(ctx context.Context, session *models.Session, courts []models.Court) error {
+	overrides, err := uc.scheduleRepo.GetDateOverrides(ctx, session.VenueID, session.SessionDate)
+	if err != nil {
+		return fmt.Errorf("failed to get schedule overrides: %w", err)
+	}
+
+	// A nil court only matches the overrides for the whole venue
+	schedule := models.AllDaySchedule()
+	schedule.ApplyOverrides(overrides, uuid.Nil)
+	if err := schedule.Check(session.StartTime, session.EndTime); err != nil {
+		return fmt.Errorf("%w: venue is not open on %s: %v", ErrValidation, session.SessionDate.Format("2006-01-02"), err)
+	}
+
+	date := session.SessionDate
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, models.BookingTimeZone)
+
+	for _, court := range courts {
+		windows, err := uc.maintenanceRepo.GetOverlapping(ctx, court.ID, dayStart, dayStart.AddDate(0, 0, 1))
+		if err != nil {
+			return fmt.Errorf("failed to get court maintenance: %w", err)
+		}
+
+		schedule := models.AllDaySchedule()
+		schedule.ApplyOverrides(overrides, court.ID)
+		schedule.ApplyMaintenance(date, windows)
+		if err := schedule.Check(session.StartTime, session.EndTime); err != nil {
+			return fmt.Errorf("%w: court %s is not open on %s: %v", ErrCourtUnavailable, court.Name, session.SessionDate.Format("2006-01-02"), err)
+		}
 	}
 
-	existingSessions, err := uc.sessionRepo.List(ctx, filters, 100, 0)
+	return nil
+}
+
+// checkSessionConflict checks if the court is already taken by a booking or another session
+func (uc *useCase) checkSessionConflict(ctx context.Context, sessionDate time.Time, startTime, endTime time.Time, courtID uuid.UUID) error {
+	existingSessions, err := uc.sessionRepo.GetCourtSessions(ctx, courtID, sessionDate)
 	if err != nil {
 		return fmt.Errorf("failed to check session conflicts: %w", err)
 	}
 
-	proposedStart := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
-		startTime.Hour(), startTime.Minute(), 0, 0, time.Local)
-	proposedEnd := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
-		endTime.Hour(), endTime.Minute(), 0, 0, time.Local)
-
 	for _, session := range existingSessions {
-		if session.Status != models.SessionStatusCancelled {
-			existingStart := time.Date(session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
-				session.StartTime.Hour(), session.StartTime.Minute(), 0, 0, time.Local)
-			existingEnd := time.Date(session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
-				session.EndTime.Hour(), session.EndTime.Minute(), 0, 0, time.Local)
-
-			if proposedStart.Before(existingEnd) && existingStart.Before(proposedEnd) {
-				return fmt.Errorf("court is already booked from %s to %s",
-					existingStart.Format("15:04"),
-					existingEnd.Format("15:04"))
-			}
+		if timesOverlap(startTime, endTime, session.StartTime, session.EndTime) {
+			return fmt.Errorf("%w: court is reserved by session %q from %s to %s",
+				ErrCourtUnavailable,
+				session.Title,
+				session.StartTime.Format("15:04"),
+				session.EndTime.Format("15:04"))
+		}
+	}
+
+	bookings, err := uc.bookingRepo.GetCourtBookings(ctx, courtID, sessionDate)
+	if err != nil {
+		return fmt.Errorf("failed to check court bookings: %w", err)
+	}
+
+	for _, booking := range bookings {
+		if booking.Status == models.BookingStatusCancelled {
+			continue
+		}
+		if timesOverlap(startTime, endTime, booking.StartTime, booking.EndTime) {
+			return fmt.Errorf("%w: court is already booked from %s to %s",
+				ErrCourtUnavailable,
+				booking.StartTime.Format("15:04"),
+				booking.EndTime.Format("15:04"))
 		}
 	}
 
 	return nil
 }
 
+// validateSessionCourts checks that the requested courts belong to the venue. Maintenance and
+// conflicts are checked per occurrence later, once the courts are locked, since a court's
+// current status says nothing about the dates the session is played on.
+func (uc *useCase) validateSessionCourts(ctx context.Context, venueID uuid.UUID, rawCourtIDs []string) ([]models.Court, error) {
+	courts := make([]models.Court, 0, len(rawCourtIDs))
+	seen := make(map[uuid.UUID]bool, len(rawCourtIDs))
+
+	for _, rawID := range rawCourtIDs {
+		courtID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid court ID %q", ErrValidation, rawID)
+		}
+		if seen[courtID] {
+			continue
+		}
+		seen[courtID] = true
+
+		court, err := uc.courtRepo.GetByID(ctx, courtID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: court %s not found", ErrValidation, courtID)
+		}
+
+		if court.VenueID != venueID {
+			return nil, fmt.Errorf("%w: court %s does not belong to this venue", ErrValidation, court.Name)
+		}
+
+		courts = append(courts, *court)
+	}
+
+	return courts, nil
+}
+
+// newSessionBooking builds the host's pending booking of a court for the session's time range.
+// The booking has no hold: it is not paid through checkout and lasts as long as the session.
+func (uc *useCase) newSessionBooking(session *models.Session, court models.Court, pricing *models.VenuePricing) models.CourtBooking {
+	notes := fmt.Sprintf("Booked for session %q", session.Title)
+	amount, _ := pricing.Price(court.ID, court.PricePerHour, session.SessionDate, session.StartTime, session.EndTime)
+	now := time.Now()
+
+	return models.CourtBooking{
+		ID:          uuid.New(),
+		CourtID:     court.ID,
+		UserID:      session.HostID,
+		SessionID:   &session.ID,
+		Date:        session.SessionDate,
+		StartTime:   session.StartTime,
+		EndTime:     session.EndTime,
+		TotalAmount: amount,
+		Status:      models.BookingStatusPending,
+		Notes:       &notes,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// timesOverlap reports whether two time-of-day ranges intersect
+func timesOverlap(startA, endA, startB, endB time.Time) bool {
+	toMinutes := func(t time.Time) int { return t.Hour()*60 + t.Minute() }
+	return toMinutes(startA) < toMinutes(endB) && toMinutes(startB) < toMinutes(endA)
+}
+
 // countParticipantsByStatus counts participants by their status
 func (uc *useCase) countParticipantsByStatus(participants []models.SessionParticipant) (confirmed, pending int) {
 	for _, p := range participants {
@@ -773,6 +1300,16 @@ func (uc *useCase) countParticipantsByStatus(participants []models.SessionPartic
 	return
 }
 
+// spotsTaken counts the participants holding a spot in the session. In a private session
+// a pending player keeps their spot while the host decides on them.
+func (uc *useCase) spotsTaken(session *models.SessionDetail, participants []models.SessionParticipant) int {
+	confirmed, pending := uc.countParticipantsByStatus(participants)
+	if session.IsPublic {
+		return confirmed
+	}
+	return confirmed + pending
+}
+
 // isParticipantInSession checks if a user is already participating
 func (uc *useCase) isParticipantInSession(participants []models.SessionParticipant, userID uuid.UUID) (bool, models.ParticipantStatus) {
 	for _, p := range participants {
@@ -783,6 +1320,94 @@ func (uc *useCase) isParticipantInSession(participants []models.SessionParticipa
 	return false, ""
 }
 
+// promoteWaitlist hands the open spots of a session to waitlisted players in the order they
+// joined, then reopens or fills the session to match. Players promoted in a private session
+// still need the host's approval, so they become pending and their spot is kept for them.
+func (uc *useCase) promoteWaitlist(ctx context.Context, session *models.SessionDetail) error {
+	if session.Status != models.SessionStatusOpen && session.Status != models.SessionStatusFull {
+		return nil
+	}
+
+	participants, err := uc.sessionRepo.GetParticipants(ctx, session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	confirmedCount, _ := uc.countParticipantsByStatus(participants)
+	taken := uc.spotsTaken(session, participants)
+	promotedStatus := models.ParticipantStatusConfirmed
+	if !session.IsPublic {
+		promotedStatus = models.ParticipantStatusPending
+	}
+
+	for _, p := range participants {
+		if taken >= session.MaxParticipants {
+			break
+		}
+		if p.Status != models.ParticipantStatusWaitlisted {
+			continue
+		}
+
+		if err := uc.sessionRepo.UpdateParticipantStatus(ctx, session.ID, p.UserID, promotedStatus); err != nil {
+			return fmt.Errorf("failed to promote waitlisted participant: %w", err)
+		}
+
+		if err := uc.addToSessionChat(ctx, session.ID, p.UserID); err != nil {
+			return err
+		}
+
+		taken++
+		if promotedStatus == models.ParticipantStatusConfirmed {
+			confirmedCount++
+		}
+	}
+
+	status := models.SessionStatusOpen
+	if confirmedCount >= session.MaxParticipants {
+		status = models.SessionStatusFull
+	}
+
+	if session.Status != status {
+		session.Status = status
+		session.UpdatedAt = time.Now()
+		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
+			return fmt.Errorf("failed to update session status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// addToSessionChat adds a player to the group chat of the session
+func (uc *useCase) addToSessionChat(ctx context.Context, sessionID, userID uuid.UUID) error {
+	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get chat ID: %w", err)
+	}
+
+	if err := uc.chatRepo.AddUserToChat(ctx, userID, chatID); err != nil {
+		return fmt.Errorf("failed to add user to chat: %w", err)
+	}
+
+	return nil
+}
+
+// waitlistPosition returns the 1-based place of a user in the waitlist,
+// given participants ordered by when they joined
+func (uc *useCase) waitlistPosition(participants []models.SessionParticipant, userID uuid.UUID) int {
+	position := 0
+	for _, p := range participants {
+		if p.Status != models.ParticipantStatusWaitlisted {
+			continue
+		}
+		position++
+		if p.UserID == userID {
+			return position
+		}
+	}
+	return 0
+}
+
 // validatePlayerLevel validates the player level
 func (uc *useCase) validatePlayerLevel(level string) error {
 	validLevels := map[string]bool{
@@ -5,6 +5,7 @@ import (
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
@@ -13,8 +14,8 @@ type UseCase interface {
 	CreateVenue(ctx context.Context, ownerID uuid.UUID, req requests.CreateVenueRequest) (*responses.VenueResponse, error)
 	GetVenue(ctx context.Context, id uuid.UUID) (*responses.VenueResponse, error)
 	UpdateVenue(ctx context.Context, id uuid.UUID, req requests.UpdateVenueRequest) error
-	ListVenues(ctx context.Context, location string, limit, offset int) ([]responses.ListVenueResponse, error)
-	SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string) (responses.VenueResponseDTO, error)
+	ListVenues(ctx context.Context, location string, geo requests.GeoQuery, limit, offset int) ([]responses.ListVenueResponse, error)
+	SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string, geo requests.GeoQuery) (responses.VenueResponseDTO, error)
 	AddCourt(ctx context.Context, venueID uuid.UUID, req requests.CreateCourtRequest) (*responses.CourtResponse, error)
 	UpdateCourt(ctx context.Context, venueID uuid.UUID, req requests.UpdateCourtRequest) error
 	DeleteCourt(ctx context.Context, venueID uuid.UUID, courtID uuid.UUID) error
@@ -23,3 +24,5 @@ type UseCase interface {
 	GetFacilities(ctx context.Context, venueID uuid.UUID) (*responses.FacilityListResponse, error)
 	IsOwner(ctx context.Context, venueID uuid.UUID, ownerID uuid.UUID) (bool, error)
 }
+
+var ErrValidation = errors.New("validation error")
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
@@ -44,6 +46,20 @@ func (uc *useCase) CreateVenue(ctx context.Context, ownerID uuid.UUID, req reque
 		UpdatedAt:   time.Now(),
 		Latitude:    req.Latitude,
 		Longitude:   req.Longitude,
+
+		BookingHoldMinutes: req.BookingHoldMinutes,
+	}
+
+	if venue.BookingHoldMinutes <= 0 {
+		venue.BookingHoldMinutes = models.DefaultBookingHoldMinutes
+	}
+
+	if len(req.CancellationPolicy) > 0 {
+		policy, err := toCancellationPolicy(req.CancellationPolicy)
+		if err != nil {
+			return nil, err
+		}
+		venue.CancellationPolicy = policy
 	}
 
 	if err := uc.venueRepo.Create(ctx, venue); err != nil {
@@ -81,6 +97,9 @@ func (uc *useCase) CreateVenue(ctx context.Context, ownerID uuid.UUID, req reque
 		Courts:       []responses.CourtResponse{},
 		Latitude:     venue.Latitude,
 		Longitude:    venue.Longitude,
+
+		BookingHoldMinutes: venue.BookingHoldMinutes,
+		CancellationPolicy: convertToCancellationTierResponse(venue),
 	}, nil
 }
 
@@ -128,6 +147,9 @@ func (uc *useCase) GetVenue(ctx context.Context, id uuid.UUID) (*responses.Venue
 		Rules:        rules,
 		Latitude:     venueWithCourts.Latitude,
 		Longitude:    venueWithCourts.Longitude,
+
+		BookingHoldMinutes: venueWithCourts.BookingHoldMinutes,
+		CancellationPolicy: convertToCancellationTierResponse(&venueWithCourts.Venue),
 	}, nil
 }
 
@@ -176,6 +198,16 @@ func (uc *useCase) UpdateVenue(ctx context.Context, id uuid.UUID, req requests.U
 	}
 	venue.Latitude = req.Latitude
 	venue.Longitude = req.Longitude
+	if req.BookingHoldMinutes > 0 {
+		venue.BookingHoldMinutes = req.BookingHoldMinutes
+	}
+	if req.CancellationPolicy != nil {
+		policy, err := toCancellationPolicy(req.CancellationPolicy)
+		if err != nil {
+			return err
+		}
+		venue.CancellationPolicy = policy
+	}
 
 	facilityUUIDs := make([]uuid.UUID, len(req.Facilities))
 	for i, facility := range req.Facilities {
@@ -198,8 +230,13 @@ func (uc *useCase) UpdateVenue(ctx context.Context, id uuid.UUID, req requests.U
 	return nil
 }
 
-func (uc *useCase) ListVenues(ctx context.Context, location string, limit, offset int) ([]responses.ListVenueResponse, error) {
-	venues, err := uc.venueRepo.List(ctx, location, limit, offset)
+func (uc *useCase) ListVenues(ctx context.Context, location string, geo requests.GeoQuery, limit, offset int) ([]responses.ListVenueResponse, error) {
+	geoQuery, err := toGeoQuery(geo)
+	if err != nil {
+		return nil, err
+	}
+
+	venues, err := uc.venueRepo.List(ctx, location, geoQuery, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list venues: %w", err)
 	}
@@ -208,15 +245,23 @@ func (uc *useCase) ListVenues(ctx context.Context, location string, limit, offse
 
 	for _, venue := range venues {
 		venueResponses = append(venueResponses, responses.ListVenueResponse{
-			ID:   venue.ID.String(),
-			Name: venue.Name,
+			ID:         venue.ID.String(),
+			Name:       venue.Name,
+			Latitude:   venue.Latitude,
+			Longitude:  venue.Longitude,
+			DistanceKm: venue.DistanceKm,
 		})
 	}
 	return venueResponses, nil
 }
 
-func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string) (responses.VenueResponseDTO, error) {
-	venues, err := uc.venueRepo.Search(ctx, query, limit, offset, minPrice, maxPrice, location, facilities)
+func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string, geo requests.GeoQuery) (responses.VenueResponseDTO, error) {
+	geoQuery, err := toGeoQuery(geo)
+	if err != nil {
+		return responses.VenueResponseDTO{}, err
+	}
+
+	venues, err := uc.venueRepo.Search(ctx, query, limit, offset, minPrice, maxPrice, location, facilities, geoQuery)
 	if err != nil {
 		return responses.VenueResponseDTO{}, fmt.Errorf("failed to search venues: %w", err)
 	}
@@ -250,9 +295,10 @@ func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset
 				}
 				return rules
 			}(),
-			Courts:    convertToCourtResponse(venue.Courts),
-			Latitude:  venue.Latitude,
-			Longitude: venue.Longitude,
+			Courts:     convertToCourtResponse(venue.Courts),
+			Latitude:   venue.Latitude,
+			Longitude:  venue.Longitude,
+			DistanceKm: venue.DistanceKm,
 		}
 	}
 
@@ -261,7 +307,7 @@ func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset
 	// 	return responses.VenueResponseDTO{}, fmt.Errorf("failed to count venues: %w", err)
 	// }
 
-	total, err := uc.venueRepo.CountSearch(ctx, query, minPrice, maxPrice, location, facilities)
+	total, err := uc.venueRepo.CountSearch(ctx, query, minPrice, maxPrice, location, facilities, geoQuery)
 	if err != nil {
 		return responses.VenueResponseDTO{}, fmt.Errorf("failed to count venues: %w", err)
 	}
@@ -342,10 +388,6 @@ func (uc *useCase) UpdateCourt(ctx context.Context, venueID uuid.UUID, req reque
 	if req.PricePerHour > 0 {
 		court.PricePerHour = req.PricePerHour
 	}
-	if req.Status != "" {
-		court.Status = models.CourtStatus(req.Status)
-	}
-
 	court.UpdatedAt = time.Now()
 
 	if err := uc.venueRepo.UpdateCourt(ctx, court); err != nil {
@@ -522,6 +564,39 @@ func convertToRuleResponse(rules []requests.Rule) []responses.RuleResponse {
 	return ruleResponses
 }
 
+// toCancellationPolicy validates the requested tiers and encodes them for storage
+func toCancellationPolicy(tiers []requests.CancellationTier) (models.NullRawMessage, error) {
+	policy := make([]models.CancellationTier, len(tiers))
+	for i, tier := range tiers {
+		policy[i] = models.CancellationTier{
+			HoursBefore:   tier.HoursBefore,
+			RefundPercent: tier.RefundPercent,
+		}
+	}
+
+	if err := models.ValidateCancellationPolicy(policy); err != nil {
+		return models.NullRawMessage{}, fmt.Errorf("invalid cancellation policy: %w", err)
+	}
+
+	return models.NullRawMessage{RawMessage: mustMarshalJSON(policy), Valid: true}, nil
+}
+
+func convertToCancellationTierResponse(venue *models.Venue) []responses.CancellationTierResponse {
+	tiers, err := venue.CancellationTiers()
+	if err != nil {
+		tiers = models.DefaultCancellationPolicy
+	}
+
+	tierResponses := make([]responses.CancellationTierResponse, len(tiers))
+	for i, tier := range tiers {
+		tierResponses[i] = responses.CancellationTierResponse{
+			HoursBefore:   tier.HoursBefore,
+			RefundPercent: tier.RefundPercent,
+		}
+	}
+	return tierResponses
+}
+
 func convertToModelFacilities(facilities []requests.Facility) []models.Facility {
 	modelFacilities := make([]models.Facility, len(facilities))
 	for i, facility := range facilities {
@@ -558,6 +633,44 @@ func convertToCourtResponse(courts []models.Court) []responses.CourtResponse {
 	return courtResponses
 }
 
+// toGeoQuery checks the geo filters of a request and parses its bounding box
+func toGeoQuery(req requests.GeoQuery) (*models.GeoQuery, error) {
+	geo := &models.GeoQuery{
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		RadiusKm:  req.RadiusKm,
+	}
+
+	if req.BBox != "" {
+		parts := strings.Split(req.BBox, ",")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("%w: bbox must be min_lat,min_lng,max_lat,max_lng", ErrValidation)
+		}
+
+		var corners [4]float64
+		for i, part := range parts {
+			value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bbox must be min_lat,min_lng,max_lat,max_lng", ErrValidation)
+			}
+			corners[i] = value
+		}
+
+		geo.Bounds = &models.GeoBounds{
+			MinLatitude:  corners[0],
+			MinLongitude: corners[1],
+			MaxLatitude:  corners[2],
+			MaxLongitude: corners[3],
+		}
+	}
+
+	if err := geo.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	return geo, nil
+}
+
 func mustMarshalJSON(v interface{}) []byte {
 	data, err := json.Marshal(v)
 	if err != nil {